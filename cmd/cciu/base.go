@@ -0,0 +1,74 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/mgumz/cciu/pkg/baseimage"
+	"github.com/mgumz/cciu/pkg/imagespec"
+	"github.com/mgumz/cciu/pkg/repo"
+	"github.com/mgumz/cciu/pkg/tag"
+)
+
+// checkBase looks up the base image of the image tagged t in the repo given
+// by spec, via its annotations: the digest the base tag points to now and
+// the newest tag of the base repo ahead of it. nil is returned if the image
+// names no base image.
+func checkBase(spec *imagespec.Spec, t string, opts *cciuOpts) (*baseimage.Image, error) {
+
+	f := opts.Fetcher
+	name := spec.String() + ":" + t
+
+	annotations := map[string]string{}
+	_, err := f.FetchImage(spec.Registry, name, func(img *repo.Image) (err error) {
+		annotations, err = img.Annotations()
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf(errFetchConfig, name, err)
+	}
+
+	base := baseimage.FromAnnotations(annotations)
+	if base == nil {
+		return nil, nil
+	}
+
+	bspec, err := imagespec.Parse(base.Name)
+	if err != nil {
+		return nil, fmt.Errorf(errParsingName, base.Name, err)
+	}
+	bspec = bspec.StripContext()
+
+	base.Current, _, err = f.FetchDigest(bspec.Registry, bspec.String())
+	if err != nil {
+		return nil, fmt.Errorf(errFetchDigest, bspec, err)
+	}
+
+	scheme := opts.Config.Image(bspec).VersionScheme()
+	btag, err := tag.ParseWithLabel(scheme, bspec.Tag, bspec.Label)
+	if err != nil {
+		// "latest", "stable": only the digest tells about updates
+		return base, nil
+	}
+
+	rtags, _, err := f.FetchTags(bspec.Registry, bspec.RegistryRepo())
+	if err != nil {
+		return nil, fmt.Errorf(errFetchTags, bspec, err)
+	}
+
+	fl := fList{}
+	fl = fl.filterScheme(btag)
+	fl = fl.filterHugeVersionGaps(btag)
+	fl = fl.filterPrereleases(btag, opts.Filter.Prerelease)
+	fl = fl.filterStrictLabels(btag, true)
+
+	tags := tag.NewFromStrings(rtags, btag.Scheme, nil, fl.apply())
+	tags.Sort()
+	tags.Reverse()
+
+	if len(tags) > 0 {
+		if verdict, _ := tag.Compare(btag, tags[0]); verdict == tag.VerdictAhead {
+			base.Newer = tags[0]
+		}
+	}
+	return base, nil
+}
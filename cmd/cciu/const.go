@@ -1,7 +1,19 @@
 package main
 
 const (
-	errParsingName  = "error parsing name %q: %s"
-	errTagNotSemver = "error: tag %q of image %q is not semver: %s"
-	errFetchTags    = "error fetching tags for %q: %s"
+	errParsingName     = "error parsing name %q: %s"
+	errTagNotSemver    = "error: tag %q of image %q is not semver: %s"
+	errFetchTags       = "error fetching tags for %q: %s"
+	errFetchDigest     = "error fetching digest for %q: %s"
+	errResolve         = "error: no tag of %q points to the same image (looked at %d tags)"
+	errFetchConfig     = "error fetching image config for %q: %s"
+	errNoLabel         = "error: image %q has no label %q"
+	errLabelNotVersion = "error: version label %q of image %q is no version: %s"
+	errNoCreated       = "error: image %q has no creation date"
+)
+
+// sources of the version of a requested image, if not the tag itself
+const (
+	sourceDigest = "digest"
+	sourceLabel  = "label"
 )
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"github.com/mgumz/cciu/pkg/imagespec"
+	"github.com/mgumz/cciu/pkg/registry"
+	"github.com/mgumz/cciu/pkg/tag"
+)
+
+// checkDigest fetches the current digest of the image given by spec if
+// there is something to compare it to: the digest the image is pinned to
+// ("alpine:3.19@sha256:…") or the digest of the previous run. The current
+// digest is kept for the next run. expected is "" if there is nothing to
+// compare, actual is "" if the digest could not be fetched.
+func checkDigest(spec *imagespec.Spec, opts *cciuOpts) (expected, actual string) {
+
+	key := spec.StripContext().Normalize().String()
+
+	expected = spec.Digest()
+	if expected == "" && opts.State == nil {
+		return "", ""
+	}
+	if expected == "" {
+		expected = opts.State[key]
+	}
+
+	actual, _, err := opts.Fetcher.FetchDigest(spec.Registry, spec.StripContext().String())
+	if err != nil {
+		return expected, ""
+	}
+
+	if opts.State != nil {
+		opts.State[key] = actual
+	}
+	return expected, actual
+}
+
+// showOldLimit limits the costly lookups with -show-old to the newest rows,
+// the older ones are printed as they are
+const showOldLimit = 10
+
+// rowLimit returns the number of rows the costly lookups (aliases,
+// platforms, …) are run for: the one printed, or the newest ones with
+// -show-old. With -tiers the lookups run per tier instead.
+func rowLimit(opts *cciuOpts) int {
+	if opts.ShowOld {
+		return showOldLimit
+	}
+	return 1
+}
+
+// digestFunc returns a tag.DigestFunc which looks up the digests of the tags
+// of the repo given by spec
+func digestFunc(spec *imagespec.Spec, f registry.Fetcher) tag.DigestFunc {
+	return func(t *tag.Tag) string {
+		digest, _, err := f.FetchDigest(spec.Registry, spec.String()+":"+t.String())
+		if err != nil {
+			return ""
+		}
+		return digest
+	}
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	imgspecv1 "github.com/opencontainers/image-spec/specs-go/v1"
+
+	"github.com/mgumz/cciu/pkg/imagespec"
+	"github.com/mgumz/cciu/pkg/registry"
+	"github.com/mgumz/cciu/pkg/repo"
+	"github.com/mgumz/cciu/pkg/tag"
+)
+
+// hoursPerYear is the length of the average year, leap years included
+const hoursPerYear = 24 * 365.25
+
+// libyears returns the age difference in years between the image of the
+// requested tag and the image of the newest tag ahead of base, according to
+// the creation dates in their configs. An image without newer tags is 0
+// libyears behind. false is returned if a creation date is unknown.
+func libyears(spec *imagespec.Spec, requested string, base *tag.Tag, tags tag.List, f registry.Fetcher) (float64, bool) {
+
+	if len(tags) == 0 {
+		return 0, true
+	}
+	if verdict, _ := tag.Compare(base, tags[0]); verdict != tag.VerdictAhead {
+		return 0, true
+	}
+
+	current, err := created(spec, requested, f)
+	if err != nil {
+		return 0, false
+	}
+	newest, err := created(spec, tags[0].String(), f)
+	if err != nil {
+		return 0, false
+	}
+
+	if age := newest.Sub(current); age > 0 {
+		return age.Hours() / hoursPerYear, true
+	}
+	return 0, true
+}
+
+// created returns the creation date of the image tagged t in the repo given
+// by spec
+func created(spec *imagespec.Spec, t string, f registry.Fetcher) (time.Time, error) {
+
+	config := &imgspecv1.Image{}
+	_, err := f.FetchImage(spec.Registry, spec.String()+":"+t, func(img *repo.Image) (err error) {
+		config, err = img.Config()
+		return err
+	})
+	if err != nil {
+		return time.Time{}, err
+	}
+	if config.Created == nil {
+		return time.Time{}, fmt.Errorf(errNoCreated, spec.String()+":"+t)
+	}
+	return *config.Created, nil
+}
@@ -1,49 +1,102 @@
 package main
 
 import (
-	"fmt"
-
 	"github.com/Masterminds/semver/v3"
 
+	"github.com/mgumz/cciu/pkg/config"
+	"github.com/mgumz/cciu/pkg/stats"
 	"github.com/mgumz/cciu/pkg/tag"
 )
 
-type fList []tag.FilterFunc
+type fList []tag.NamedFilter
 
-func (list fList) filterHugeVersionGaps(base *semver.Version) fList {
-	f := tag.HugeVersionHeuristicFilter(base, 1000)
-	return append(list, f)
+func (list fList) add(name string, f tag.FilterFunc) fList {
+	return append(list, tag.NamedFilter{Name: name, Filter: f})
 }
 
-func (list fList) filterBetaVersions(doFilter bool) fList {
-	if !doFilter {
+func (list fList) filterScheme(base *tag.Tag) fList {
+	return list.add("scheme", tag.SchemeFilter(base))
+}
+
+func (list fList) filterHugeVersionGaps(base *tag.Tag) fList {
+	if base.SemVer() == nil {
 		return list
 	}
-	f := func(v *semver.Version) bool {
-		return tag.IgnoreBetaVersions(v)
+	f := tag.HugeVersionHeuristicFilter(base.SemVer(), 1000)
+	return list.add("huge-version-gap", f)
+}
+
+func (list fList) filterPrereleases(base *tag.Tag, policy *tag.PrereleasePolicy) fList {
+	if policy == nil {
+		return list
 	}
-	return append(list, f)
+	return list.add("prerelease", policy.Filter(base))
 }
 
-func (list fList) filterStrictLabels(label string, doFilter bool) fList {
+func (list fList) filterStrictLabels(base *tag.Tag, doFilter bool) fList {
 	if !doFilter {
 		return list
 	}
-	f := func(v *semver.Version) bool {
-		return v.Prerelease() == label
+	return list.add("strict-labels", tag.VariantFilter(base.Variant))
+}
+
+func (list fList) filterKeepLevel(base *tag.Tag, keepLevel int) fList {
+	if keepLevel == tag.Ignore {
+		return list
 	}
-	return append(list, f)
+	return list.add("keep", base.Scheme.Keep(base, keepLevel))
 }
 
-func (list fList) filterKeepLevel(base *semver.Version, keepLevel int) fList {
-	if keepLevel == tag.KeepMajor {
-		cs := fmt.Sprintf("~%d", base.Major())
-		c, _ := semver.NewConstraint(cs)
-		return append(list, tag.ConstraintFilter(c))
-	} else if keepLevel == tag.KeepMinor {
-		cs := fmt.Sprintf("~%d.%d", base.Major(), base.Minor())
-		c, _ := semver.NewConstraint(cs)
-		return append(list, tag.ConstraintFilter(c))
+// filterConstraints only applies the constraints to bases which can be
+// checked against them: a (global) semver constraint does not filter out
+// the tags of calendar versions or codenames
+func (list fList) filterConstraints(base *tag.Tag, constraints ...*semver.Constraints) fList {
+	if !base.Constrainable() {
+		return list
+	}
+	for _, c := range constraints {
+		if c != nil {
+			list = list.add("constraint", tag.ConstraintFilter(c))
+		}
 	}
 	return list
 }
+
+// candidateFilters returns the filters which decide about the candidate
+// tags for base, as configured for img and via opts
+func candidateFilters(base *tag.Tag, img *config.Image, opts *cciuOpts) fList {
+
+	fl := fList{}
+	fl = fl.filterScheme(base)
+	fl = fl.filterHugeVersionGaps(base)
+	fl = fl.filterPrereleases(base, img.PrereleasePolicy(opts.Filter.Prerelease))
+	fl = fl.filterStrictLabels(base, opts.Filter.StrictLabels)
+	fl = fl.filterKeepLevel(base, img.KeepLevel(opts.Filter.Keep))
+	fl = fl.filterConstraints(base, opts.Filter.Constraint, img.VersionConstraint())
+	return fl
+}
+
+// apply returns a tag.FilterFunc which applies all filters of the list
+func (list fList) apply() tag.FilterFunc {
+	funcs := make([]tag.FilterFunc, 0, len(list))
+	for _, f := range list {
+		funcs = append(funcs, f.Filter)
+	}
+	return tag.ApplyFilterList(funcs)
+}
+
+// filterNames returns a tag.NameFilterFunc which applies all patterns and
+// counts the names filtered out (if stats is given)
+func filterNames(stats *stats.AllStats, patterns ...*tag.NamePatterns) tag.NameFilterFunc {
+	return func(name string) bool {
+		for _, p := range patterns {
+			if !p.Match(name) {
+				if stats != nil {
+					stats.FilteredByPattern++
+				}
+				return false
+			}
+		}
+		return true
+	}
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/mgumz/cciu/pkg/imagespec"
+	"github.com/mgumz/cciu/pkg/registry"
+	"github.com/mgumz/cciu/pkg/tag"
+)
+
+// resolveLimit limits the number of tags looked up to resolve a floating tag
+const resolveLimit = 25
+
+// resolveFloating resolves the floating tag of spec ("latest", "3") to the
+// most specific tag of the repo pointing to the same image. floating is the
+// parsed floating tag; nil if the tag is no version at all.
+func resolveFloating(spec *imagespec.Spec, floating *tag.Tag, rtags []string, scheme tag.Scheme, f registry.Fetcher) (*tag.Tag, error) {
+
+	digest, _, err := f.FetchDigest(spec.Registry, spec.StripContext().String())
+	if err != nil {
+		return nil, fmt.Errorf(errFetchDigest, spec, err)
+	}
+
+	switch {
+	case floating != nil:
+		scheme = floating.Scheme
+	case scheme == nil:
+		scheme = tag.SemVerScheme
+	}
+
+	tags := tag.NewFromStrings(rtags, scheme, nil, tag.ApplyFilterList(nil))
+	tags.Sort()
+	tags.Reverse()
+
+	repo := *spec
+	repo.Tag, repo.Label, repo.Context = "", "", ""
+
+	resolved := tags.Resolve(floating, digest, digestFunc(&repo, f), resolveLimit)
+	if resolved == nil {
+		return nil, fmt.Errorf(errResolve, spec, resolveLimit)
+	}
+	return resolved, nil
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"fmt"
+
+	imgspecv1 "github.com/opencontainers/image-spec/specs-go/v1"
+
+	"github.com/mgumz/cciu/pkg/imagespec"
+	"github.com/mgumz/cciu/pkg/registry"
+	"github.com/mgumz/cciu/pkg/repo"
+	"github.com/mgumz/cciu/pkg/tag"
+)
+
+// versionFromLabel parses the version label ("org.opencontainers.image.version")
+// of the image config of spec
+func versionFromLabel(spec *imagespec.Spec, scheme tag.Scheme, f registry.Fetcher) (*tag.Tag, error) {
+
+	config := &imgspecv1.Image{}
+	_, err := f.FetchImage(spec.Registry, spec.StripContext().String(), func(img *repo.Image) (err error) {
+		config, err = img.Config()
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf(errFetchConfig, spec, err)
+	}
+
+	version := config.Config.Labels[imgspecv1.AnnotationVersion]
+	if version == "" {
+		return nil, fmt.Errorf(errNoLabel, spec, imgspecv1.AnnotationVersion)
+	}
+
+	t, err := tag.ParseWithLabel(scheme, version, "")
+	if err != nil {
+		return nil, fmt.Errorf(errLabelNotVersion, version, spec, err)
+	}
+	return t, nil
+}
@@ -4,33 +4,91 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"slices"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/Masterminds/semver/v3"
 
+	"github.com/mgumz/cciu/pkg/config"
+	"github.com/mgumz/cciu/pkg/eol"
 	"github.com/mgumz/cciu/pkg/imagespec"
 	"github.com/mgumz/cciu/pkg/printer"
 	"github.com/mgumz/cciu/pkg/registry"
 	"github.com/mgumz/cciu/pkg/registry/fetcher"
+	"github.com/mgumz/cciu/pkg/signature"
+	"github.com/mgumz/cciu/pkg/state"
 	"github.com/mgumz/cciu/pkg/stats"
 	"github.com/mgumz/cciu/pkg/tag"
+	"github.com/mgumz/cciu/pkg/vuln"
 )
 
 type cciuRepoTags struct {
 	Tags     []string
 	Duration time.Duration
 	FetchErr error
+
+	// counted tells whether the tags filtered by pattern were counted
+	// already: the same repo might be requested with several tags
+	counted bool
 }
 
 type cciuOpts struct {
 	Filter struct {
-		IgnoreBeta    bool
+		Prerelease    *tag.PrereleasePolicy
 		StrictLabels  bool
 		SkipNonSemVer bool
 		Keep          int
+		Constraint    *semver.Constraints
 	}
 
+	ShowTiers   bool
+	ShowOld     bool
+	Explain     bool
+	ShowAliases bool
+	ShowDrift   bool
+
+	ResolveFloating bool
+	VersionLabel    bool
+
+	// Platforms lists the platforms candidate tags must be available for,
+	// tags missing one are dropped or, with MarkPlatforms, marked
+	Platforms     []string
+	MarkPlatforms bool
+
+	// Signatures verifies the signatures of candidate tags; nil if not
+	// checked. With SkipUnsigned, tags which are not signed are skipped.
+	Signatures   *signature.Verifier
+	SkipUnsigned bool
+
+	// ShowReferrers shows the artifact types attached to the current and
+	// the candidate tags; candidates lacking one of RequireArtifacts are
+	// skipped
+	ShowReferrers    bool
+	RequireArtifacts []string
+
+	// ShowSize shows the compressed size of the candidate tags and their
+	// difference to the size of the requested image
+	ShowSize bool
+
+	// CheckBase checks whether the base image of the requested image was
+	// updated since it was built
+	CheckBase bool
+
+	// EOL holds the release cycles of the repos; nil if not checked. Cycles
+	// ending within EOLWarn are reported as near their end of life.
+	EOL     eol.Dataset
+	EOLWarn time.Duration
+
+	// Vulns holds the vulnerability reports of the images; nil if none were
+	// given. Vulnerable images are checked first.
+	Vulns vuln.Reports
+
+	// State holds the digests of the previous run; nil if not kept
+	State state.Digests
+
+	Config  *config.Config
 	Fetcher registry.Fetcher
 	Printer printer.Printer
 	Stats   *stats.AllStats
@@ -40,18 +98,40 @@ func main() {
 
 	opts := &cciuOpts{Stats: &stats.AllStats{}}
 
-	flag.BoolVar(&opts.Filter.IgnoreBeta, "exclude-beta-tags", false, "exclude 'beta' tags")
 	flag.BoolVar(&opts.Filter.StrictLabels, "strict-labels", false, "strict label matching")
-	flag.BoolVar(&opts.Filter.SkipNonSemVer, "skip-non-semver", false, "skip non-semver tags")
-
-	keepVersion := flag.String("keep", "", "keep [major|minor] version")
+	flag.BoolVar(&opts.Filter.SkipNonSemVer, "skip-non-semver", false, "skip tags following none of the version schemes")
+	flag.BoolVar(&opts.ShowTiers, "tiers", false, "show newest patch, minor and major update")
+	flag.BoolVar(&opts.Explain, "explain", false, "explain why tags were filtered out")
+	flag.BoolVar(&opts.ShowOld, "show-old", false, "show older tags")
+	flag.BoolVar(&opts.ShowDrift, "drift", false, "show how far behind images are (releases, libyears)")
+	flag.BoolVar(&opts.ShowAliases, "aliases", false, "collapse tags pointing to the same image")
+	flag.BoolVar(&opts.VersionLabel, "version-label", false, "use the version label of non-version tags")
+	flag.BoolVar(&opts.MarkPlatforms, "mark-platforms", false, "mark tags missing a required platform instead of skipping them")
+	flag.BoolVar(&opts.ResolveFloating, "resolve-floating", false, "resolve floating tags (latest, 3) via their digest")
+
+	doExcludeBeta := flag.Bool("exclude-beta-tags", false, "exclude pre-release tags ('beta', 'rc', …)")
+	keepVersion := flag.String("keep", "", "keep [major|minor|patch] version")
+	constraint := flag.String("constraint", "", "only consider versions matching the semver constraint")
 	doPrettyPrintJSON := flag.Bool("json-pretty", false, "indent json output")
 	doPrintJSON := flag.Bool("json", false, "use json output format")
 	doUseSimpleMarkers := flag.Bool("simple-markers", false, "use simple ascii markers")
 	doShowStats := flag.Bool("stats", false, "show stats")
-	doShowOldTags := flag.Bool("show-old", false, "show older tags")
 	doLimitPerRegistry := flag.Int("limit-per-registry", 0, "limit parallel fetches per registry")
 	fetchTimeout := flag.Duration("timeout", 0, "timeout for fetch operations")
+	configPath := flag.String("config", "", "path to the config file")
+	platforms := flag.String("platform", "", "required platforms of candidate tags (linux/arm64,linux/amd64)")
+	cosignKeyPath := flag.String("cosign-key", "", "verify the cosign signatures of candidate tags against the public key")
+	flag.BoolVar(&opts.SkipUnsigned, "skip-unsigned", false, "skip candidate tags without a valid signature")
+	flag.BoolVar(&opts.ShowReferrers, "referrers", false, "show the artifact types (SBOMs, attestations) attached to tags")
+	requireArtifacts := flag.String("require-artifact", "", "skip candidate tags lacking the artifact types (spdx,in-toto,…)")
+	flag.BoolVar(&opts.ShowSize, "size", false, "show the compressed size of tags and the delta to the current one")
+	flag.BoolVar(&opts.CheckBase, "base", false, "check whether the base image was updated since the image was built")
+	doCheckEOL := flag.Bool("eol", false, "flag images whose release cycle is (near) end of life")
+	eolDataPath := flag.String("eol-data", "", "path to an endoflife.date dataset or [repo=]response, extending the bundled one")
+	eolWarnDays := flag.Int("eol-warn", 90, "days before the end of life to flag an image as near eol")
+	doFailOnEOL := flag.Bool("fail-on-eol", false, "exit with an error if an image is end of life")
+	vulnReports := flag.String("vuln-report", "", "paths to Trivy or Grype JSON reports of the images (a.json,b.json)")
+	statePath := flag.String("state", "", "path to the file keeping the digests between runs")
 	//authFilePath := flag.String("auth-file", "", "path to the credential store")
 	doShowVersion := flag.Bool("version", false, "show version")
 
@@ -63,16 +143,104 @@ func main() {
 		return
 	}
 
-	switch *keepVersion {
-	case "":
-	case "major":
-		opts.Filter.Keep = tag.KeepMajor
-	case "minor":
-		opts.Filter.Keep = tag.KeepMinor
-	default:
+	if *configPath != "" {
+		cfg, err := config.Load(*configPath)
+		if err != nil {
+			os.Exit(printConfigError(*configPath, err))
+			return
+		}
+		cfg.Apply()
+		opts.Config = cfg
+	}
+
+	var prerelease *tag.PrereleasePolicy
+	if *doExcludeBeta {
+		prerelease = tag.DefaultPrereleasePolicy()
+	}
+	opts.Filter.Prerelease = opts.Config.PrereleasePolicy(prerelease)
+
+	keep, err := tag.ParseKeepLevel(*keepVersion)
+	if err != nil {
 		os.Exit(printUnsupportedMinMajorLevel(*keepVersion))
 		return
 	}
+	opts.Filter.Keep = keep
+
+	if *constraint != "" {
+		c, err := semver.NewConstraint(*constraint)
+		if err != nil {
+			os.Exit(printInvalidConstraint(*constraint, err))
+			return
+		}
+		opts.Filter.Constraint = c
+	}
+
+	if *platforms != "" {
+		p, err := tag.ParsePlatforms(*platforms)
+		if err != nil {
+			os.Exit(printInvalidPlatform(*platforms, err))
+			return
+		}
+		opts.Platforms = p
+	}
+
+	if *requireArtifacts != "" {
+		for _, a := range strings.Split(*requireArtifacts, ",") {
+			if a = strings.TrimSpace(a); a != "" {
+				opts.RequireArtifacts = append(opts.RequireArtifacts, a)
+			}
+		}
+		opts.ShowReferrers = true
+	}
+
+	if *cosignKeyPath != "" {
+		v, err := signature.LoadPublicKey(*cosignKeyPath)
+		if err != nil {
+			os.Exit(printKeyError(*cosignKeyPath, err))
+			return
+		}
+		opts.Signatures = v
+	}
+
+	if *doCheckEOL || *eolDataPath != "" || *doFailOnEOL {
+		opts.EOL = eol.Bundled()
+		if *eolDataPath != "" {
+			repo, path, found := strings.Cut(*eolDataPath, "=")
+			if !found {
+				repo, path = "", *eolDataPath
+			}
+			data, err := eol.Load(repo, path)
+			if err != nil {
+				os.Exit(printEOLDataError(*eolDataPath, err))
+				return
+			}
+			opts.EOL = opts.EOL.Merge(data)
+		}
+		opts.EOLWarn = time.Duration(*eolWarnDays) * 24 * time.Hour
+	}
+
+	if *vulnReports != "" {
+		for _, path := range strings.Split(*vulnReports, ",") {
+			if path = strings.TrimSpace(path); path == "" {
+				continue
+			}
+			report, err := vuln.Load(path)
+			if err != nil {
+				os.Exit(printVulnReportError(path, err))
+				return
+			}
+			opts.Vulns = append(opts.Vulns, report)
+		}
+	}
+
+	if *statePath != "" {
+		digests, err := state.Load(*statePath)
+		if err != nil {
+			os.Exit(printStateError(*statePath, err))
+			return
+		}
+		opts.State = digests
+	}
 
 	opts.Printer = printer.NewTextPrinter(os.Stdout, *doUseSimpleMarkers)
 	if *doPrintJSON || *doPrettyPrintJSON {
@@ -82,7 +250,7 @@ func main() {
 		}
 		opts.Printer = jp
 	}
-	opts.Printer.SetShowOldTags(*doShowOldTags)
+	opts.Printer.SetShowOldTags(opts.ShowOld)
 	opts.Printer.SetShowStats(*doShowStats)
 
 	opts.Fetcher = fetcher.NewSimple()
@@ -90,12 +258,23 @@ func main() {
 		opts.Fetcher = fetcher.NewPerRegistry(*doLimitPerRegistry)
 	}
 	opts.Fetcher.SetTimeout(*fetchTimeout)
+	opts.Fetcher = fetcher.NewCached(opts.Fetcher)
 	//opts.Fetcher.SetAuthFilePath(*authFilePath)
 
 	ts := time.Now()
 	fetchAndCompare(flag.Args(), opts)
 	opts.Stats.Duration = time.Since(ts)
 	opts.Printer.Flush(opts.Stats)
+
+	if opts.State != nil {
+		if err := opts.State.Save(*statePath); err != nil {
+			os.Exit(printStateError(*statePath, err))
+		}
+	}
+
+	if *doFailOnEOL && opts.Stats.EOL > 0 {
+		os.Exit(printEOLImages(opts.Stats.EOL))
+	}
 }
 
 type fetchedTags map[string]*cciuRepoTags
@@ -130,17 +309,26 @@ func fetchAndCompare(names []string, opts *cciuOpts) {
 			continue
 		}
 
-		// skip images without any tag
+		// skip images without any tag, unless these are resolved as
+		// "latest"
 		// TODO: decide if print something
 		if spec.Tag == "" {
-			stats.NonTagged++
-			continue
+			if !opts.ResolveFloating {
+				stats.NonTagged++
+				continue
+			}
+			spec.Tag = "latest"
 		}
 
-		// skip images without semver tag
+		// skip images without semver tag (or rather: a tag which follows
+		// none of the known version schemes), unless the tag is floating
+		// ("latest") and resolved via its digest or the version is read
+		// from the label of the image
 		if opts.Filter.SkipNonSemVer {
-			_, err := semver.NewVersion(spec.Tag)
-			if err != nil {
+			scheme := opts.Config.Image(spec).VersionScheme()
+			_, err := tag.ParseWithLabel(scheme, spec.Tag, spec.Label)
+			resolvable := opts.VersionLabel || (opts.ResolveFloating && tag.IsFloatingName(spec.Tag))
+			if err != nil && !resolvable {
 				stats.NonSemVer++
 				//note: intentionally _not_ printing the error "skip-non-semver"
 				//  the following line afterwards was used before:
@@ -175,6 +363,10 @@ func fetchAndCompare(names []string, opts *cciuOpts) {
 
 	wg.Wait()
 
+	if opts.Vulns != nil {
+		prioritize(specs, tags, opts)
+	}
+
 	for _, spec := range specs {
 		compareAndPrint(spec, tags, opts)
 	}
@@ -184,8 +376,12 @@ func compareAndPrint(spec *imagespec.Spec, rtags map[string]*cciuRepoTags, opts
 
 	prt, stats := opts.Printer, opts.Stats
 
-	v, err := semver.NewVersion(spec.Tag)
-	if err != nil {
+	img := opts.Config.Image(spec)
+
+	base, err := tag.ParseWithLabel(img.VersionScheme(), spec.Tag, spec.Label)
+	fromLabel := opts.VersionLabel && err != nil
+	floating := opts.ResolveFloating && (err != nil || base.IsFloating())
+	if err != nil && !floating && !fromLabel {
 		stats.NonSemVer++
 		if !opts.Filter.SkipNonSemVer {
 			err = fmt.Errorf(errTagNotSemver, spec.Tag, spec, err)
@@ -202,23 +398,221 @@ func compareAndPrint(spec *imagespec.Spec, rtags map[string]*cciuRepoTags, opts
 		return
 	}
 
-	fl := fList{}
-	fl = fl.filterHugeVersionGaps(v)
-	fl = fl.filterBetaVersions(opts.Filter.IgnoreBeta)
-	fl = fl.filterStrictLabels(spec.Label, opts.Filter.StrictLabels)
-	fl = fl.filterKeepLevel(v, opts.Filter.Keep)
+	requestedTag := spec.Tag
+	if spec.Label != "" {
+		requestedTag += "-" + spec.Label
+	}
 
-	tags := tag.NewFromStrings(rt.Tags, tag.ApplyFilterList(fl))
+	// the requested tag itself might be gone or re-pushed
+	missing := !slices.Contains(rt.Tags, requestedTag)
+	expected, actual := "", ""
+	if missing {
+		stats.Missing++
+		if base == nil {
+			prt.NewSpec(spec.String(), rt.Duration, nil)
+			prt.PrintVerdict(spec.String(), tag.VerdictMissing, "", "")
+			return
+		}
+		fromLabel, floating = false, false
+	} else {
+		expected, actual = checkDigest(spec, opts)
+	}
+	mutated := expected != "" && actual != "" && expected != actual
+
+	// the version of the base tag might come from elsewhere
+	source := ""
+	if fromLabel {
+		labeled, err := versionFromLabel(spec, img.VersionScheme(), opts.Fetcher)
+		switch {
+		case err == nil:
+			base, floating, source = labeled, false, sourceLabel
+		case !floating:
+			stats.NonSemVer++
+			if opts.Filter.SkipNonSemVer {
+				err = nil
+			}
+			prt.NewSpec(spec.String(), rt.Duration, err)
+			return
+		}
+	}
+	if floating {
+		base, err = resolveFloating(spec, base, rt.Tags, img.VersionScheme(), opts.Fetcher)
+		if err != nil {
+			prt.NewSpec(spec.String(), rt.Duration, err)
+			return
+		}
+		source = sourceDigest
+	}
+
+	fl := candidateFilters(base, img, opts)
+
+	patterns := []*tag.NamePatterns{opts.Config.TagPatterns(), img.TagPatterns()}
+	counter := stats
+	if rt.counted {
+		counter = nil
+	}
+	rt.counted = true
+	nf := filterNames(counter, patterns...)
+
+	tags := tag.NewFromStrings(rt.Tags, base.Scheme, nf, fl.apply())
 	tags.Sort()
 	tags.Reverse()
 
-	prt.NewSpec(spec.String(), rt.Duration, nil)
-
+	requested := spec.String()
+	report := opts.Vulns.Lookup(spec)
 	spec.Tag, spec.Label, spec.Context = "", "", ""
 
-	for _, tag := range tags {
-		prt.PrintTag(spec.String(), v, tag)
+	if opts.ShowTiers {
+		// only the newest suitable tag per tier is shown: look for it tier
+		// by tier instead of looking up every tag
+		checked := tag.List{}
+		for _, candidates := range tags.TierCandidates(base) {
+			checked = append(checked, lookupTags(spec, base, rt.Tags, candidates, 1, opts)...)
+		}
+		tags = checked
+		tags.Sort()
+		tags.Reverse()
+	} else {
+		tags = lookupTags(spec, base, rt.Tags, tags, rowLimit(opts), opts)
+	}
+
+	complete := tags.Complete()
+	updatable := false
+	if len(complete) > 0 {
+		if verdict, kind := tag.Compare(base, complete[0]); verdict == tag.VerdictAhead {
+			stats.CountUpdate(string(kind))
+			updatable = true
+		}
+	}
+
+	drift := tag.Drift{}
+	if opts.ShowDrift {
+		drift = complete.Drift(base)
+		if !missing {
+			drift.Libyears, drift.HasLibyears = libyears(spec, requestedTag, base, complete, opts.Fetcher)
+		}
+		stats.Behind.Releases += drift.Releases
+		stats.Behind.Majors += drift.Majors
+		stats.Behind.Minors += drift.Minors
+		stats.Behind.Libyears += drift.Libyears
+	}
+
+	prt.NewSpec(requested, rt.Duration, nil)
+
+	switch {
+	case missing:
+		prt.PrintVerdict(requested, tag.VerdictMissing, "", "")
+	case mutated:
+		stats.Mutated++
+		prt.PrintVerdict(requested, tag.VerdictMutated, expected, actual)
+	}
+
+	if source != "" {
+		prt.PrintResolved(requestedTag, base, source)
+	}
+
+	if opts.ShowReferrers && !missing {
+		if types, err := fetchReferrers(spec, requestedTag, opts.Fetcher); err == nil {
+			prt.PrintReferrers(requested, types)
+		}
+	}
+
+	if opts.CheckBase && !missing {
+		switch base, err := checkBase(spec, requestedTag, opts); {
+		case err != nil:
+			prt.PrintBase(requested, nil, err)
+		case base != nil:
+			if base.Updated() {
+				stats.RebuildNeeded++
+			}
+			prt.PrintBase(requested, base, nil)
+		}
+	}
+
+	if opts.EOL != nil {
+		if cycle := opts.EOL.Lookup(spec, base); cycle != nil {
+			status := cycle.Status(time.Now(), opts.EOLWarn)
+			switch status {
+			case eol.StatusEOL:
+				stats.EOL++
+			case eol.StatusNearEOL:
+				stats.NearEOL++
+			}
+			prt.PrintCycle(requested, cycle, status)
+		}
+	}
+
+	if report != nil {
+		summary := report.Summary()
+		if summary.Total() > 0 {
+			stats.Vulnerable++
+		}
+		stats.CountVulns(summary.Counts)
+		prt.PrintVulns(requested, summary, updatable)
+	}
+
+	if opts.Explain {
+		prt.Explain(tag.Explain(rt.Tags, base.Scheme, filterNames(nil, patterns...), fl))
+	}
+
+	tiers := tag.Tiers{}
+	if opts.ShowTiers {
+		tiers = complete.Tiers(base)
+	}
+
+	if opts.ShowSize {
+		platform := sizePlatform(opts)
+		if !missing {
+			base.Size = fetchSize(spec, requestedTag, platform, opts.Fetcher)
+		}
+		sizeOf := func(t *tag.Tag) int64 { return fetchSize(spec, t.String(), platform, opts.Fetcher) }
+		if opts.ShowTiers {
+			for _, t := range []*tag.Tag{tiers.Patch, tiers.Minor, tiers.Major} {
+				if t != nil {
+					t.Size = sizeOf(t)
+				}
+			}
+		} else {
+			tags.LookupSizes(sizeOf, rowLimit(opts))
+		}
+	}
+
+	if opts.ShowTiers {
+		prt.PrintTiers(spec.String(), base, tiers)
+	} else {
+		for _, tag := range tags {
+			prt.PrintTag(spec.String(), base, tag)
+		}
+	}
+
+	if opts.ShowDrift {
+		prt.PrintDrift(spec.String(), drift)
 	}
 
 	stats.Checked++
 }
+
+// lookupTags runs the costly lookups deciding about the tags of the repo
+// given by spec (sorted, newest first) ahead of base until limit tags pass
+// them: the platforms, the signatures, the referrers and the aliases of the
+// tags. rtags are the tags of the repo.
+func lookupTags(spec *imagespec.Spec, base *tag.Tag, rtags []string, tags tag.List, limit int, opts *cciuOpts) tag.List {
+
+	if len(opts.Platforms) > 0 {
+		tags = tags.RequirePlatforms(base, opts.Platforms, platformFunc(spec, opts.Fetcher), limit, opts.MarkPlatforms)
+	}
+
+	if opts.Signatures != nil {
+		tags = tags.RequireSignatures(base, signatureFunc(spec, rtags, opts.Signatures, opts.Fetcher), limit, opts.SkipUnsigned)
+	}
+
+	if opts.ShowReferrers {
+		tags = tags.RequireArtifacts(base, opts.RequireArtifacts, referrerFunc(spec, opts.Fetcher), limit)
+	}
+
+	if opts.ShowAliases {
+		tags = tags.Collapse(limit, digestFunc(spec, opts.Fetcher))
+	}
+
+	return tags
+}
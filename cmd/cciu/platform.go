@@ -0,0 +1,24 @@
+package main
+
+import (
+	"github.com/mgumz/cciu/pkg/imagespec"
+	"github.com/mgumz/cciu/pkg/registry"
+	"github.com/mgumz/cciu/pkg/repo"
+	"github.com/mgumz/cciu/pkg/tag"
+)
+
+// platformFunc returns a tag.PlatformFunc which looks up the platforms of
+// the tags of the repo given by spec via their manifest list / OCI index
+func platformFunc(spec *imagespec.Spec, f registry.Fetcher) tag.PlatformFunc {
+	return func(t *tag.Tag) []string {
+		platforms := []string{}
+		_, err := f.FetchImage(spec.Registry, spec.String()+":"+t.String(), func(img *repo.Image) (err error) {
+			platforms, err = img.Platforms()
+			return err
+		})
+		if err != nil {
+			return nil
+		}
+		return platforms
+	}
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"slices"
+
+	"github.com/mgumz/cciu/pkg/imagespec"
+	"github.com/mgumz/cciu/pkg/registry"
+	"github.com/mgumz/cciu/pkg/tag"
+)
+
+// referrerFunc returns a tag.ReferrerFunc which looks up the artifact types
+// of the referrers of the tags of the repo given by spec
+func referrerFunc(spec *imagespec.Spec, f registry.Fetcher) tag.ReferrerFunc {
+	return func(t *tag.Tag) []string {
+		types, err := fetchReferrers(spec, t.String(), f)
+		if err != nil {
+			return nil
+		}
+		return types
+	}
+}
+
+// fetchReferrers fetches the artifact types of the referrers of the image
+// tagged t in the repo given by spec
+func fetchReferrers(spec *imagespec.Spec, t string, f registry.Fetcher) ([]string, error) {
+
+	digest, _, err := f.FetchDigest(spec.Registry, spec.String()+":"+t)
+	if err != nil {
+		return nil, err
+	}
+
+	descs, _, err := f.FetchReferrers(spec.Registry, spec.String(), digest)
+	if err != nil {
+		return nil, err
+	}
+
+	types := []string{}
+	for _, d := range descs {
+		if d.ArtifactType != "" && !slices.Contains(types, d.ArtifactType) {
+			types = append(types, d.ArtifactType)
+		}
+	}
+	return types, nil
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"slices"
+
+	"github.com/mgumz/cciu/pkg/imagespec"
+	"github.com/mgumz/cciu/pkg/registry"
+	"github.com/mgumz/cciu/pkg/repo"
+	"github.com/mgumz/cciu/pkg/signature"
+	"github.com/mgumz/cciu/pkg/tag"
+)
+
+// signatureFunc returns a tag.SignatureFunc which verifies the cosign
+// signatures of the tags of the repo given by spec. The signatures are
+// looked up under the tag cosign uses, "sha256-<digest>.sig" (if in rtags,
+// the tags of the repo), and among the OCI 1.1 referrers, as signatures or
+// sigstore bundles; if these can not be looked up, the signatures under the
+// tag are verified alone.
+func signatureFunc(spec *imagespec.Spec, rtags []string, v *signature.Verifier, f registry.Fetcher) tag.SignatureFunc {
+
+	digestOf := digestFunc(spec, f)
+
+	return func(t *tag.Tag) (string, bool) {
+
+		digest := digestOf(t)
+		if digest == "" {
+			return "", false
+		}
+
+		names := []string{}
+		if sigTag := signature.Tag(digest); slices.Contains(rtags, sigTag) {
+			names = append(names, spec.String()+":"+sigTag)
+		}
+
+		referrers, _, _ := f.FetchReferrers(spec.Registry, spec.String(), digest)
+		for _, d := range referrers {
+			if signature.IsArtifactType(d.ArtifactType) {
+				names = append(names, spec.String()+"@"+d.Digest.String())
+			}
+		}
+
+		sigs := []signature.Signature{}
+		for _, name := range names {
+			found := []signature.Signature{}
+			_, err := f.FetchImage(spec.Registry, name, func(img *repo.Image) (err error) {
+				found, err = img.Signatures()
+				return err
+			})
+			if err != nil {
+				return "", false
+			}
+			sigs = append(sigs, found...)
+		}
+
+		status := v.Verify(sigs, digest)
+		return string(status), status == signature.StatusSigned
+	}
+}
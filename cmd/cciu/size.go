@@ -0,0 +1,31 @@
+package main
+
+import (
+	"github.com/mgumz/cciu/pkg/imagespec"
+	"github.com/mgumz/cciu/pkg/registry"
+	"github.com/mgumz/cciu/pkg/repo"
+)
+
+// sizePlatform returns the platform to measure the images for: the first
+// one required via -platform, the current one ("") otherwise
+func sizePlatform(opts *cciuOpts) string {
+	if len(opts.Platforms) > 0 {
+		return opts.Platforms[0]
+	}
+	return ""
+}
+
+// fetchSize fetches the compressed size of the image tagged t in the repo
+// given by spec for platform; 0 if unknown
+func fetchSize(spec *imagespec.Spec, t, platform string, f registry.Fetcher) int64 {
+
+	size := int64(0)
+	_, err := f.FetchImage(spec.Registry, spec.String()+":"+t, func(img *repo.Image) (err error) {
+		size, err = img.Size(platform)
+		return err
+	})
+	if err != nil {
+		return 0
+	}
+	return size
+}
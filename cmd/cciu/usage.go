@@ -24,3 +24,51 @@ func printUnsupportedMinMajorLevel(level string) int {
 	fmt.Fprintf(os.Stderr, "Ignoring unknown version Level: %s\n", level)
 	return 13
 }
+
+func printConfigError(path string, err error) int {
+
+	fmt.Fprintf(os.Stderr, "Error reading config %q: %s\n", path, err)
+	return 14
+}
+
+func printInvalidConstraint(constraint string, err error) int {
+
+	fmt.Fprintf(os.Stderr, "Invalid constraint %q: %s\n", constraint, err)
+	return 15
+}
+
+func printStateError(path string, err error) int {
+
+	fmt.Fprintf(os.Stderr, "Error with state file %q: %s\n", path, err)
+	return 16
+}
+
+func printInvalidPlatform(platforms string, err error) int {
+
+	fmt.Fprintf(os.Stderr, "Invalid platform %q: %s\n", platforms, err)
+	return 17
+}
+
+func printEOLDataError(path string, err error) int {
+
+	fmt.Fprintf(os.Stderr, "Error reading eol dataset %q: %s\n", path, err)
+	return 18
+}
+
+func printEOLImages(n int) int {
+
+	fmt.Fprintf(os.Stderr, "Found %d end of life image(s)\n", n)
+	return 19
+}
+
+func printKeyError(path string, err error) int {
+
+	fmt.Fprintf(os.Stderr, "Error reading public key %q: %s\n", path, err)
+	return 20
+}
+
+func printVulnReportError(path string, err error) int {
+
+	fmt.Fprintf(os.Stderr, "Error reading vulnerability report %q: %s\n", path, err)
+	return 21
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/mgumz/cciu/pkg/imagespec"
+	"github.com/mgumz/cciu/pkg/tag"
+)
+
+// prioritize sorts specs by their vulnerability reports: outdated images
+// with vulnerabilities come first, then the other vulnerable ones, each the
+// most severe first. The order of images of the same rank is kept.
+func prioritize(specs imagespec.List, rtags fetchedTags, opts *cciuOpts) {
+
+	type rank struct {
+		outdated bool
+		score    int
+	}
+
+	ranks := make(map[*imagespec.Spec]rank, len(specs))
+	for _, spec := range specs {
+		report := opts.Vulns.Lookup(spec)
+		if report == nil {
+			continue
+		}
+		r := rank{score: report.Summary().Score()}
+		r.outdated = r.score > 0 && outdated(spec, rtags[spec.RegistryRepo()], opts)
+		ranks[spec] = r
+	}
+
+	slices.SortStableFunc(specs, func(a, b *imagespec.Spec) int {
+		ra, rb := ranks[a], ranks[b]
+		if ra.outdated != rb.outdated {
+			if ra.outdated {
+				return -1
+			}
+			return 1
+		}
+		return cmp.Compare(rb.score, ra.score)
+	})
+}
+
+// outdated reports whether one of the fetched tags is a candidate ahead of
+// the requested one. It looks at the tags only: images whose version comes
+// from a label or a floating tag resolved via -resolve-floating count as
+// current.
+func outdated(spec *imagespec.Spec, rt *cciuRepoTags, opts *cciuOpts) bool {
+
+	if rt == nil || rt.FetchErr != nil {
+		return false
+	}
+
+	img := opts.Config.Image(spec)
+	base, err := tag.ParseWithLabel(img.VersionScheme(), spec.Tag, spec.Label)
+	if err != nil || (opts.ResolveFloating && base.IsFloating()) {
+		return false
+	}
+
+	patterns := []*tag.NamePatterns{opts.Config.TagPatterns(), img.TagPatterns()}
+	tags := tag.NewFromStrings(rt.Tags, base.Scheme, filterNames(nil, patterns...), candidateFilters(base, img, opts).apply())
+	for _, t := range tags {
+		if verdict, _ := tag.Compare(base, t); verdict == tag.VerdictAhead {
+			return true
+		}
+	}
+	return false
+}
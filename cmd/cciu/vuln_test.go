@@ -0,0 +1,51 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mgumz/cciu/pkg/imagespec"
+	"github.com/mgumz/cciu/pkg/tag"
+	"github.com/mgumz/cciu/pkg/vuln"
+)
+
+func TestPrioritize(t *testing.T) {
+
+	reports := vuln.Reports{
+		{Images: []string{"alpine:3.19"}, Vulnerabilities: []vuln.Vulnerability{{Severity: "high"}}},
+		{Images: []string{"nginx:1.27.2"}, Vulnerabilities: []vuln.Vulnerability{{Severity: "critical"}}},
+	}
+	rtags := fetchedTags{
+		"alpine": {Tags: []string{"3.19", "3.19.1", "3.20", "3.20.2", "3.20.3"}},
+		"nginx":  {Tags: []string{"1.27.1", "1.27.2"}},
+	}
+
+	fixtures := [...]struct {
+		ResolveFloating bool
+		Expected        []string
+	}{
+		{false, []string{"alpine:3.19", "nginx:1.27.2", "busybox:1.36"}},
+		{true, []string{"nginx:1.27.2", "alpine:3.19", "busybox:1.36"}},
+	}
+
+	for _, f := range fixtures {
+
+		specs := imagespec.List{}
+		for _, name := range []string{"busybox:1.36", "nginx:1.27.2", "alpine:3.19"} {
+			spec, _ := imagespec.Parse(name)
+			specs = append(specs, spec)
+		}
+
+		opts := &cciuOpts{Vulns: reports, ResolveFloating: f.ResolveFloating}
+		opts.Filter.Keep = tag.Ignore
+		prioritize(specs, rtags, opts)
+
+		actual := []string{}
+		for _, spec := range specs {
+			actual = append(actual, spec.String())
+		}
+		if strings.Join(actual, "|") != strings.Join(f.Expected, "|") {
+			t.Fatalf("resolve floating %t: expected: %q, actual: %q", f.ResolveFloating, f.Expected, actual)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package baseimage
+
+import (
+	imgspecv1 "github.com/opencontainers/image-spec/specs-go/v1"
+
+	"github.com/mgumz/cciu/pkg/tag"
+)
+
+// Image is the base image an image was built from, as given by the
+// annotations "org.opencontainers.image.base.name" and
+// "org.opencontainers.image.base.digest" of the image. Current is the
+// digest Name points to now, Newer the newest tag of the repo of the base
+// image ahead of the tag of Name (nil if there is none).
+type Image struct {
+	Name    string
+	Digest  string
+	Current string
+	Newer   *tag.Tag
+}
+
+// FromAnnotations returns the base image given by the annotations; nil if
+// they name none
+func FromAnnotations(annotations map[string]string) *Image {
+
+	name := annotations[imgspecv1.AnnotationBaseImageName]
+	if name == "" {
+		return nil
+	}
+	return &Image{
+		Name:   name,
+		Digest: annotations[imgspecv1.AnnotationBaseImageDigest],
+	}
+}
+
+// Repushed reports whether the tag of the base image points to another
+// image than at build time
+func (img *Image) Repushed() bool {
+	return img.Digest != "" && img.Current != "" && img.Digest != img.Current
+}
+
+// Updated reports whether the base image was updated since the image was
+// built: re-pushed or superseded by a newer version. The image needs a
+// rebuild.
+func (img *Image) Updated() bool {
+	return img.Repushed() || img.Newer != nil
+}
@@ -0,0 +1,44 @@
+package baseimage
+
+import (
+	"testing"
+
+	"github.com/mgumz/cciu/pkg/tag"
+)
+
+func TestUpdated(t *testing.T) {
+
+	newer, _ := tag.Parse("3.20.1")
+	annotations := map[string]string{
+		"org.opencontainers.image.base.name":   "docker.io/library/alpine:3.20",
+		"org.opencontainers.image.base.digest": "sha256:a",
+	}
+
+	fixtures := [...]struct {
+		Name             string
+		Current          string
+		Newer            *tag.Tag
+		ExpectedRepushed bool
+		ExpectedUpdated  bool
+	}{
+		{"same digest", "sha256:a", nil, false, false},
+		{"re-pushed", "sha256:b", nil, true, true},
+		{"newer version", "sha256:a", newer, false, true},
+		{"unknown digest", "", nil, false, false},
+	}
+
+	for _, f := range fixtures {
+		img := FromAnnotations(annotations)
+		img.Current, img.Newer = f.Current, f.Newer
+		if actual := img.Repushed(); actual != f.ExpectedRepushed {
+			t.Fatalf("%s: expected: %t, actual: %t", f.Name, f.ExpectedRepushed, actual)
+		}
+		if actual := img.Updated(); actual != f.ExpectedUpdated {
+			t.Fatalf("%s: expected: %t, actual: %t", f.Name, f.ExpectedUpdated, actual)
+		}
+	}
+
+	if img := FromAnnotations(map[string]string{}); img != nil {
+		t.Fatalf("expected: nil, actual: %+v", img)
+	}
+}
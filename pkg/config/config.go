@@ -0,0 +1,296 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+
+	"github.com/Masterminds/semver/v3"
+
+	"github.com/mgumz/cciu/pkg/imagespec"
+	"github.com/mgumz/cciu/pkg/tag"
+)
+
+// Config holds the settings which can be given via a JSON config file:
+//
+//	{
+//	  "codenames": {
+//	    "debian": ["buster", "bullseye", "bookworm", "trixie"]
+//	  },
+//	  "schemes": {
+//	    "build": {
+//	      "pattern": "^build-(?P<build>\\d+)$"
+//	    }
+//	  },
+//	  "images": [
+//	    { "match": "example.com/team/*", "scheme": "build" }
+//	  ]
+//	}
+type Config struct {
+	// Codenames overrides or extends the built-in ordering of distribution
+	// codenames, see tag.DefaultCodenames
+	Codenames tag.Codenames `json:"codenames,omitempty"`
+
+	// Schemes defines additional version schemes, see tag.RegexScheme
+	Schemes map[string]Scheme `json:"schemes,omitempty"`
+
+	// Prerelease defines which pre-release channels are considered
+	Prerelease *Prerelease `json:"prerelease,omitempty"`
+
+	// Tags filters the tags of all images by their names
+	Tags *Tags `json:"tags,omitempty"`
+
+	// Images contains settings per image. The first entry matching an image
+	// applies.
+	Images []Image `json:"images,omitempty"`
+}
+
+// Scheme defines a tag.RegexScheme
+type Scheme struct {
+	Pattern string           `json:"pattern"`
+	Groups  []tag.RegexGroup `json:"groups,omitempty"`
+}
+
+// Prerelease defines a tag.PrereleasePolicy: the channels ("rc", "beta",
+// "nightly", …) to allow or to exclude, given as regular expressions
+type Prerelease struct {
+	Allow   []string `json:"allow,omitempty"`
+	Exclude []string `json:"exclude,omitempty"`
+
+	policy *tag.PrereleasePolicy
+}
+
+// Tags defines tag.NamePatterns: regular expressions the raw tag names have
+// to match ("include") or must not match ("exclude")
+type Tags struct {
+	Include []string `json:"include,omitempty"`
+	Exclude []string `json:"exclude,omitempty"`
+
+	patterns *tag.NamePatterns
+}
+
+// Image holds the settings for the images matching Match. Match is a
+// pattern (see path.Match) which is checked against the registry and repo
+// part of the image as given ("alpine", "quay.io/org/app") and in its
+// normalized form ("docker.io/library/alpine").
+//
+// Keep ("major", "minor", "patch") overrides the -keep flag, Constraint
+// (eg ">=1.24 <1.27") is applied in addition to the -constraint flag,
+// Prerelease overrides the global pre-release policy, Tags is applied in
+// addition to the global tag patterns.
+type Image struct {
+	Match      string      `json:"match"`
+	Scheme     string      `json:"scheme,omitempty"`
+	Keep       string      `json:"keep,omitempty"`
+	Constraint string      `json:"constraint,omitempty"`
+	Prerelease *Prerelease `json:"prerelease,omitempty"`
+	Tags       *Tags       `json:"tags,omitempty"`
+
+	scheme     tag.Scheme
+	keep       int
+	constraint *semver.Constraints
+}
+
+// Load reads the config file at path and validates it
+func Load(path string) (*Config, error) {
+
+	data, err := os.ReadFile(path) // #nosec G304
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &Config{}
+	if err := json.Unmarshal(data, cfg); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.compile(); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// compile builds the schemes and resolves the references to them
+func (cfg *Config) compile() error {
+
+	schemes := map[string]tag.Scheme{}
+	for name, s := range cfg.Schemes {
+		scheme, err := tag.NewRegexScheme(name, s.Pattern, s.Groups)
+		if err != nil {
+			return err
+		}
+		schemes[name] = scheme
+	}
+
+	if err := cfg.Prerelease.compile(); err != nil {
+		return fmt.Errorf("prerelease: %w", err)
+	}
+	if err := cfg.Tags.compile(); err != nil {
+		return fmt.Errorf("tags: %w", err)
+	}
+
+	for i := range cfg.Images {
+		img := &cfg.Images[i]
+		if _, err := path.Match(img.Match, ""); err != nil {
+			return fmt.Errorf("images[%d]: invalid match %q: %w", i, img.Match, err)
+		}
+		if err := img.compile(schemes); err != nil {
+			return fmt.Errorf("images[%d]: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+func (img *Image) compile(schemes map[string]tag.Scheme) error {
+
+	var err error
+
+	if img.Scheme != "" {
+		if scheme, ok := schemes[img.Scheme]; ok {
+			img.scheme = scheme
+		} else if scheme, ok := tag.LookupScheme(img.Scheme); ok {
+			img.scheme = scheme
+		} else {
+			return fmt.Errorf("unknown scheme %q", img.Scheme)
+		}
+	}
+
+	if img.keep, err = tag.ParseKeepLevel(img.Keep); err != nil {
+		return err
+	}
+
+	if img.Constraint != "" {
+		if img.constraint, err = semver.NewConstraint(img.Constraint); err != nil {
+			return fmt.Errorf("invalid constraint %q: %w", img.Constraint, err)
+		}
+	}
+
+	if err := img.Prerelease.compile(); err != nil {
+		return fmt.Errorf("prerelease: %w", err)
+	}
+	if err := img.Tags.compile(); err != nil {
+		return fmt.Errorf("tags: %w", err)
+	}
+
+	return nil
+}
+
+func (p *Prerelease) compile() error {
+
+	if p == nil {
+		return nil
+	}
+
+	var err error
+	p.policy, err = tag.NewPrereleasePolicy(p.Allow, p.Exclude)
+	return err
+}
+
+func (t *Tags) compile() error {
+
+	if t == nil {
+		return nil
+	}
+
+	var err error
+	t.patterns, err = tag.NewNamePatterns(t.Include, t.Exclude)
+	return err
+}
+
+// Apply activates the settings of cfg
+func (cfg *Config) Apply() {
+
+	if len(cfg.Codenames) > 0 {
+		tag.SetCodenames(cfg.Codenames)
+	}
+}
+
+// PrereleasePolicy returns the global pre-release policy; def if not
+// configured
+func (cfg *Config) PrereleasePolicy(def *tag.PrereleasePolicy) *tag.PrereleasePolicy {
+
+	if cfg == nil || cfg.Prerelease == nil {
+		return def
+	}
+	return cfg.Prerelease.policy
+}
+
+// TagPatterns returns the global tag patterns; nil if not configured
+func (cfg *Config) TagPatterns() *tag.NamePatterns {
+
+	if cfg == nil || cfg.Tags == nil {
+		return nil
+	}
+	return cfg.Tags.patterns
+}
+
+// Image returns the settings for spec; nil if no entry matches spec
+func (cfg *Config) Image(spec *imagespec.Spec) *Image {
+
+	if cfg == nil {
+		return nil
+	}
+
+	n := *spec
+	names := []string{spec.RegistryRepo(), n.Normalize().RegistryRepo()}
+
+	for i := range cfg.Images {
+		for _, name := range names {
+			if ok, _ := path.Match(cfg.Images[i].Match, name); ok {
+				return &cfg.Images[i]
+			}
+		}
+	}
+	return nil
+}
+
+// VersionScheme returns the scheme to use for the image; nil if the scheme
+// should be detected
+func (img *Image) VersionScheme() tag.Scheme {
+
+	if img == nil {
+		return nil
+	}
+	return img.scheme
+}
+
+// KeepLevel returns the keep level for the image; def if not configured
+func (img *Image) KeepLevel(def int) int {
+
+	if img == nil || img.keep == tag.Ignore {
+		return def
+	}
+	return img.keep
+}
+
+// VersionConstraint returns the constraint for the image; nil if not
+// configured
+func (img *Image) VersionConstraint() *semver.Constraints {
+
+	if img == nil {
+		return nil
+	}
+	return img.constraint
+}
+
+// PrereleasePolicy returns the pre-release policy for the image; def if not
+// configured
+func (img *Image) PrereleasePolicy(def *tag.PrereleasePolicy) *tag.PrereleasePolicy {
+
+	if img == nil || img.Prerelease == nil {
+		return def
+	}
+	return img.Prerelease.policy
+}
+
+// TagPatterns returns the tag patterns for the image; nil if not configured
+func (img *Image) TagPatterns() *tag.NamePatterns {
+
+	if img == nil || img.Tags == nil {
+		return nil
+	}
+	return img.Tags.patterns
+}
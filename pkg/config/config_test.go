@@ -0,0 +1,136 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/mgumz/cciu/pkg/imagespec"
+	"github.com/mgumz/cciu/pkg/tag"
+)
+
+func TestImage(t *testing.T) {
+
+	cfg := &Config{
+		Schemes: map[string]Scheme{
+			"build": {Pattern: `^build-(?P<build>\d+)$`},
+		},
+		Images: []Image{
+			{Match: "example.com/team/*", Scheme: "build"},
+			{Match: "docker.io/library/ubuntu", Scheme: "calver"},
+		},
+	}
+	if err := cfg.compile(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	fixtures := [...]struct {
+		Image          string
+		ExpectedScheme string
+	}{
+		{"example.com/team/app:build-12", "build"},
+		{"example.com/other/app:build-12", ""},
+		{"ubuntu:jammy-20240427", "calver"},
+		{"alpine:3.19", ""},
+	}
+
+	for _, f := range fixtures {
+		spec, _ := imagespec.Parse(f.Image)
+		scheme := cfg.Image(spec).VersionScheme()
+
+		name := ""
+		if scheme != nil {
+			name = scheme.Name()
+		}
+		if f.ExpectedScheme != name {
+			t.Fatalf("%q: expected: %q, actual: %q", f.Image, f.ExpectedScheme, name)
+		}
+	}
+}
+
+func TestImageFilters(t *testing.T) {
+
+	cfg := &Config{
+		Images: []Image{
+			{Match: "golang", Keep: "minor"},
+			{Match: "postgres", Constraint: ">=15 <17"},
+		},
+	}
+	if err := cfg.compile(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	fixtures := [...]struct {
+		Image         string
+		Default       int
+		ExpectedKeep  int
+		HasConstraint bool
+	}{
+		{"golang:1.24.2", tag.KeepMajor, tag.KeepMinor, false},
+		{"postgres:16.3", tag.KeepMajor, tag.KeepMajor, true},
+		{"alpine:3.19", tag.Ignore, tag.Ignore, false},
+	}
+
+	for _, f := range fixtures {
+		spec, _ := imagespec.Parse(f.Image)
+		img := cfg.Image(spec)
+		if keep := img.KeepLevel(f.Default); keep != f.ExpectedKeep {
+			t.Fatalf("%q: expected: %d, actual: %d", f.Image, f.ExpectedKeep, keep)
+		}
+		if hc := img.VersionConstraint() != nil; hc != f.HasConstraint {
+			t.Fatalf("%q: expected: %t, actual: %t", f.Image, f.HasConstraint, hc)
+		}
+	}
+}
+
+func TestPrereleasePolicy(t *testing.T) {
+
+	def := tag.DefaultPrereleasePolicy()
+	configured := &Config{Prerelease: &Prerelease{Allow: []string{"rc"}}}
+	if err := configured.compile(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	fixtures := [...]struct {
+		Name     string
+		Config   *Config
+		Default  *tag.PrereleasePolicy
+		Tag      string
+		Expected bool
+	}{
+		{"no config", nil, def, "1.2.0-rc.1", false},
+		{"no policy", &Config{}, def, "1.2.0-rc.1", false},
+		{"no policy, no default", &Config{}, nil, "1.2.0-rc.1", true},
+		{"allow rc", configured, def, "1.2.0-rc.1", true},
+		{"allow rc", configured, def, "1.2.0-beta.1", false},
+	}
+
+	base, _ := tag.SemVerScheme.Parse("1.1.0")
+	for _, f := range fixtures {
+		t1, _ := tag.SemVerScheme.Parse(f.Tag)
+		p := f.Config.PrereleasePolicy(f.Default)
+		actual := p == nil || p.Filter(base)(t1)
+		if actual != f.Expected {
+			t.Fatalf("%s, %q: expected: %t, actual: %t", f.Name, f.Tag, f.Expected, actual)
+		}
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+
+	fixtures := [...]Config{
+		{Images: []Image{{Match: "example.com/*", Scheme: "unknown"}}},
+		{Images: []Image{{Match: "[", Scheme: "semver"}}},
+		{Schemes: map[string]Scheme{"broken": {Pattern: `^(?P<build>\d+$`}}},
+		{Images: []Image{{Match: "alpine", Keep: "everything"}}},
+		{Images: []Image{{Match: "alpine", Constraint: ">=> 1.2"}}},
+		{Images: []Image{{Match: "alpine", Prerelease: &Prerelease{Allow: []string{"rc("}}}}},
+		{Prerelease: &Prerelease{Exclude: []string{"[dev"}}},
+		{Tags: &Tags{Include: []string{"(v"}}},
+		{Images: []Image{{Match: "alpine", Tags: &Tags{Exclude: []string{"*"}}}}},
+	}
+
+	for i, cfg := range fixtures {
+		if err := cfg.compile(); err == nil {
+			t.Fatalf("fixture %d: expected error", i)
+		}
+	}
+}
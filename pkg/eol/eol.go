@@ -0,0 +1,186 @@
+package eol
+
+import (
+	"bytes"
+	_ "embed" // bundled dataset
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/mgumz/cciu/pkg/imagespec"
+	"github.com/mgumz/cciu/pkg/tag"
+)
+
+// Status tells whether a release cycle is still supported
+type Status string
+
+// The states of a release cycle
+const (
+	StatusSupported Status = "supported"
+	StatusNearEOL   Status = "near-eol"
+	StatusEOL       Status = "eol"
+)
+
+// Cycle is a release cycle ("3.19", "18") as listed by endoflife.date
+// (https://endoflife.date/api/alpine.json); other fields are ignored
+type Cycle struct {
+	Cycle       string `json:"cycle"`
+	ReleaseDate string `json:"releaseDate,omitempty"`
+	EOL         Date   `json:"eol"`
+	Latest      string `json:"latest,omitempty"`
+}
+
+// Date is the end of life of a Cycle. endoflife.date gives either a date
+// ("2025-11-01") or a flag: true (reached, date unknown), false (no end of
+// life announced).
+type Date struct {
+	At      time.Time
+	Reached bool
+}
+
+const dateLayout = "2006-01-02"
+
+// UnmarshalJSON parses a date or a flag into d
+func (d *Date) UnmarshalJSON(data []byte) error {
+
+	var flag bool
+	if err := json.Unmarshal(data, &flag); err == nil {
+		*d = Date{Reached: flag}
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("eol: expected date or bool, got %s", data)
+	}
+	at, err := time.Parse(dateLayout, s)
+	if err != nil {
+		return err
+	}
+	*d = Date{At: at}
+	return nil
+}
+
+// String returns the date of d; "" if unknown
+func (d Date) String() string {
+	if d.At.IsZero() {
+		return ""
+	}
+	return d.At.Format(dateLayout)
+}
+
+// Status returns the state of the cycle at now: near its end of life, if
+// that is within warn
+func (c *Cycle) Status(now time.Time, warn time.Duration) Status {
+
+	switch {
+	case c.EOL.Reached, !c.EOL.At.IsZero() && !now.Before(c.EOL.At):
+		return StatusEOL
+	case !c.EOL.At.IsZero() && now.Add(warn).After(c.EOL.At):
+		return StatusNearEOL
+	}
+	return StatusSupported
+}
+
+// Dataset maps repos ("alpine", "docker.io/library/alpine",
+// "quay.io/org/app") to their release cycles:
+//
+//	{
+//	  "alpine": [
+//	    { "cycle": "3.19", "eol": "2025-11-01" },
+//	    { "cycle": "3.18", "eol": "2025-05-09" }
+//	  ]
+//	}
+type Dataset map[string][]Cycle
+
+//go:embed eol.json
+var bundled []byte
+
+// Bundled returns the dataset shipped with cciu, a snapshot of some products
+// of endoflife.date
+func Bundled() Dataset {
+
+	d := Dataset{}
+	if err := json.Unmarshal(bundled, &d); err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// Load reads the dataset from the file at path. The file is either a
+// Dataset or a plain endoflife.date response (curl -o alpine.json
+// https://endoflife.date/api/alpine.json), a list of cycles. The cycles of
+// such a list belong to repo; if that is "", to the base name of the file
+// ("alpine.json" => "alpine").
+func Load(repo, path string) (Dataset, error) {
+
+	data, err := os.ReadFile(path) // #nosec G304
+	if err != nil {
+		return nil, err
+	}
+
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("[")) {
+		cycles := []Cycle{}
+		if err := json.Unmarshal(data, &cycles); err != nil {
+			return nil, err
+		}
+		if repo == "" {
+			repo = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+		}
+		return Dataset{repo: cycles}, nil
+	}
+
+	if repo != "" {
+		return nil, fmt.Errorf("eol: repo %q given for a dataset, expected a list of cycles", repo)
+	}
+	d := Dataset{}
+	if err := json.Unmarshal(data, &d); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// Merge returns the dataset d, extended by o. The cycles of a repo in o
+// replace the ones in d.
+func (d Dataset) Merge(o Dataset) Dataset {
+
+	merged := Dataset{}
+	for repo, cycles := range d {
+		merged[repo] = cycles
+	}
+	for repo, cycles := range o {
+		merged[repo] = cycles
+	}
+	return merged
+}
+
+// officialPrefix is the prefix of the normalized names of official images
+const officialPrefix = "docker.io/library/"
+
+// Lookup returns the release cycle of the repo of spec which contains the
+// version of base ("3.19" for "3.19.1"); nil if there is none. The repo is
+// looked up as given, in its normalized form and, for official images, by
+// its short name ("docker.io/library/alpine" => "alpine").
+func (d Dataset) Lookup(spec *imagespec.Spec, base *tag.Tag) *Cycle {
+
+	n := *spec
+	normalized := n.Normalize().RegistryRepo()
+	repos := []string{spec.RegistryRepo(), normalized, strings.TrimPrefix(normalized, officialPrefix)}
+
+	for _, repo := range repos {
+		cycles := d[repo]
+		for i := range cycles {
+			ct, err := base.Scheme.Parse(cycles[i].Cycle)
+			if err != nil {
+				continue
+			}
+			if ct.Version.Contains(base.Version) {
+				return &cycles[i]
+			}
+		}
+	}
+	return nil
+}
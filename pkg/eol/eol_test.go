@@ -0,0 +1,122 @@
+package eol
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mgumz/cciu/pkg/imagespec"
+	"github.com/mgumz/cciu/pkg/tag"
+)
+
+func TestLookup(t *testing.T) {
+
+	data := Bundled().Merge(Dataset{
+		"quay.io/org/app": {{Cycle: "2", EOL: Date{Reached: true}}},
+	})
+	now := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+	warn := 90 * 24 * time.Hour
+
+	fixtures := [...]struct {
+		Name           string
+		ExpectedCycle  string
+		ExpectedStatus Status
+	}{
+		{"alpine:3.11", "3.11", StatusEOL},
+		{"docker.io/library/alpine:3.19.1", "3.19", StatusNearEOL},
+		{"alpine:3.21.3-r1", "3.21", StatusSupported},
+		{"golang:1.24.2", "1.24", StatusSupported},
+		{"node:18.20.1-alpine", "18", StatusEOL},
+		{"quay.io/org/app:2.4.0", "2", StatusEOL},
+		{"alpine:2.7", "", ""},
+		{"example.com/app:1.0.0", "", ""},
+	}
+
+	for _, f := range fixtures {
+		spec, _ := imagespec.Parse(f.Name)
+		base, err := tag.ParseWithLabel(tag.SemVerScheme, spec.Tag, spec.Label)
+		if err != nil {
+			t.Fatalf("%q: %s", f.Name, err)
+		}
+		cycle := data.Lookup(spec, base)
+		if cycle == nil {
+			if f.ExpectedCycle != "" {
+				t.Fatalf("%q: expected: %q, actual: none", f.Name, f.ExpectedCycle)
+			}
+			continue
+		}
+		if cycle.Cycle != f.ExpectedCycle {
+			t.Fatalf("%q: expected: %q, actual: %q", f.Name, f.ExpectedCycle, cycle.Cycle)
+		}
+		if actual := cycle.Status(now, warn); actual != f.ExpectedStatus {
+			t.Fatalf("%q: expected: %q, actual: %q", f.Name, f.ExpectedStatus, actual)
+		}
+	}
+}
+
+func TestDate(t *testing.T) {
+
+	fixtures := [...]struct {
+		In              string
+		ExpectedDate    string
+		ExpectedReached bool
+		ExpectedErr     bool
+	}{
+		{`"2025-11-01"`, "2025-11-01", false, false},
+		{`true`, "", true, false},
+		{`false`, "", false, false},
+		{`"soon"`, "", false, true},
+		{`42`, "", false, true},
+	}
+
+	for _, f := range fixtures {
+		d := Date{}
+		err := json.Unmarshal([]byte(f.In), &d)
+		if (err != nil) != f.ExpectedErr {
+			t.Fatalf("%s: expected error: %t, actual: %v", f.In, f.ExpectedErr, err)
+		}
+		if d.String() != f.ExpectedDate || d.Reached != f.ExpectedReached {
+			t.Fatalf("%s: expected: %q/%t, actual: %q/%t", f.In, f.ExpectedDate, f.ExpectedReached, d, d.Reached)
+		}
+	}
+}
+
+func TestLoad(t *testing.T) {
+
+	dir := t.TempDir()
+	files := map[string]string{
+		"dataset.json": `{"quay.io/org/app": [{"cycle": "2", "eol": true}]}`,
+		"alpine.json":  `[{"cycle": "3.22", "eol": "2027-05-01"}, {"cycle": "3.21", "eol": "2026-11-01"}]`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	fixtures := [...]struct {
+		Repo           string
+		File           string
+		ExpectedRepo   string
+		ExpectedCycles int
+		ExpectedErr    bool
+	}{
+		{"", "dataset.json", "quay.io/org/app", 1, false},
+		{"", "alpine.json", "alpine", 2, false},
+		{"docker.io/library/alpine", "alpine.json", "docker.io/library/alpine", 2, false},
+		{"alpine", "dataset.json", "", 0, true},
+		{"", "missing.json", "", 0, true},
+	}
+
+	for _, f := range fixtures {
+		d, err := Load(f.Repo, filepath.Join(dir, f.File))
+		if (err != nil) != f.ExpectedErr {
+			t.Fatalf("%s=%s: expected error: %t, actual: %v", f.Repo, f.File, f.ExpectedErr, err)
+		}
+		if actual := len(d[f.ExpectedRepo]); actual != f.ExpectedCycles || len(d) > 1 {
+			t.Fatalf("%s=%s: expected: %d cycles of %q, actual: %v", f.Repo, f.File, f.ExpectedCycles, f.ExpectedRepo, d)
+		}
+	}
+}
@@ -1,5 +1,7 @@
 package imagespec
 
+import "regexp"
+
 // Spec describes a container image spec.
 //
 // A container-image name is constructed from several pieces:
@@ -107,3 +109,16 @@ func (spec *Spec) StripContext() *Spec {
 
 	return &n
 }
+
+// reDigest matches an image digest as given in "alpine:3.19@sha256:…"
+var reDigest = regexp.MustCompile(`^sha256:[0-9a-f]{64}$`)
+
+// Digest returns the digest the image is pinned to ("alpine:3.19@sha256:…");
+// "" if the context part of spec is no digest
+func (spec *Spec) Digest() string {
+
+	if reDigest.MatchString(spec.Context) {
+		return spec.Context
+	}
+	return ""
+}
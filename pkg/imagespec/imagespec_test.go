@@ -0,0 +1,25 @@
+package imagespec
+
+import "testing"
+
+func TestDigest(t *testing.T) {
+
+	digest := "sha256:8d99168167baa6a6a0d7851b9684625df9c1455116a9601835c2127df2aaa2f5"
+
+	fixtures := [...]struct {
+		Image    string
+		Expected string
+	}{
+		{"alpine:3.19@" + digest, digest},
+		{"alpine:3.19-label@" + digest, digest},
+		{"alpine:3.19@sample-ctx", ""},
+		{"alpine:3.19", ""},
+	}
+
+	for _, f := range fixtures {
+		spec, _ := Parse(f.Image)
+		if actual := spec.Digest(); actual != f.Expected {
+			t.Fatalf("%q: expected: %q, actual: %q", f.Image, f.Expected, actual)
+		}
+	}
+}
@@ -5,9 +5,11 @@ import (
 	"io"
 	"time"
 
-	"github.com/Masterminds/semver/v3"
-
+	"github.com/mgumz/cciu/pkg/baseimage"
+	"github.com/mgumz/cciu/pkg/eol"
 	"github.com/mgumz/cciu/pkg/stats"
+	"github.com/mgumz/cciu/pkg/tag"
+	"github.com/mgumz/cciu/pkg/vuln"
 )
 
 // JSONPrinter collects the requested images, the fetched tags and creates
@@ -65,17 +67,98 @@ type jsonOutput struct {
 
 type jsonImage struct {
 	Requested string `json:"requested"`
-	Verdict   string `json:"verdict"` // "ahead", "current", "outdated"
+	Verdict   string `json:"verdict"`             // "outdated", "equal", "ahead"
+	TagState  string `json:"tag_state,omitempty"` // "missing", "mutated"
+	Resolved  string `json:"resolved,omitempty"`  // "latest" => "3.19.1"
+	Source    string `json:"source,omitempty"`    // "digest", "label"
+	Digest    string `json:"digest,omitempty"`
+	Expected  string `json:"expected_digest,omitempty"`
+
+	Tags  []jsonTag `json:"tags"`
+	Patch *jsonTag  `json:"patch,omitempty"`
+	Minor *jsonTag  `json:"minor,omitempty"`
+	Major *jsonTag  `json:"major,omitempty"`
+
+	Behind *jsonDrift `json:"behind,omitempty"`
+	Cycle  *jsonCycle `json:"cycle,omitempty"`
+
+	Referrers []string  `json:"referrers,omitempty"` // artifact types
+	Base      *jsonBase `json:"base,omitempty"`
+	Size      int64     `json:"size,omitempty"` // compressed, in bytes
+
+	Vulns *jsonVulns `json:"vulnerabilities,omitempty"`
 
-	Tags     []jsonTag     `json:"tags"`
-	Duration time.Duration `json:"duration"`
-	Err      error         `json:"error,omitempty"`
+	Decisions []jsonDecision `json:"decisions,omitempty"`
+	Duration  time.Duration  `json:"duration"`
+	Err       error          `json:"error,omitempty"`
 }
 
 type jsonTag struct {
-	Name    string `json:"name"`
-	Version string `json:"version"`
-	Verdict string `json:"verdict"` // "ahead", "current", "outdated"
+	Name    string   `json:"name"`
+	Tag     string   `json:"tag"`              // as published: "v2.4.7", "3.13"
+	Version string   `json:"version"`          // normalized: "2.4.7", "3.13.0"
+	Verdict string   `json:"verdict"`          // "ahead", "equal", "outdated"
+	Update  string   `json:"update,omitempty"` // "major", "minor", "patch", …
+	Aliases []string `json:"aliases,omitempty"`
+
+	Platforms        []string `json:"platforms,omitempty"`         // "linux/amd64", …
+	MissingPlatforms []string `json:"missing_platforms,omitempty"` // required, but not available
+
+	Signature string   `json:"signature,omitempty"` // "signed", "unsigned", "other-key", "invalid"
+	Referrers []string `json:"referrers,omitempty"` // artifact types
+
+	Size      int64  `json:"size,omitempty"`       // compressed, in bytes
+	SizeDelta *int64 `json:"size_delta,omitempty"` // to the requested image
+
+	verdict tag.Verdict
+}
+
+type jsonDrift struct {
+	Releases int      `json:"releases"`
+	Majors   int      `json:"majors"`
+	Minors   int      `json:"minors"`
+	Libyears *float64 `json:"libyears,omitempty"`
+}
+
+type jsonCycle struct {
+	Cycle  string `json:"cycle"`
+	Status string `json:"status"` // "supported", "near-eol", "eol"
+	EOL    string `json:"eol,omitempty"`
+	Latest string `json:"latest,omitempty"`
+}
+
+type jsonVulns struct {
+	Counts  map[string]int `json:"counts"`  // per severity
+	Fixable int            `json:"fixable"` // of OS packages, fixed versions known
+
+	LikelyFixes int `json:"likely_fixes,omitempty"` // fixable, if there is an update
+}
+
+type jsonBase struct {
+	Name          string `json:"name,omitempty"`
+	Digest        string `json:"digest,omitempty"`         // at build time
+	Current       string `json:"current_digest,omitempty"` // now
+	Newer         string `json:"newer,omitempty"`          // newer tag
+	RebuildNeeded bool   `json:"rebuild_needed"`
+	Err           string `json:"error,omitempty"`
+}
+
+type jsonDecision struct {
+	Tag      string `json:"tag"`
+	Kept     bool   `json:"kept"`
+	Reason   string `json:"reason,omitempty"` // "pattern", "parse", "filter"
+	Error    string `json:"error,omitempty"`
+	Scheme   string `json:"scheme,omitempty"`
+	Version  string `json:"version,omitempty"`
+	Revision int    `json:"revision,omitempty"`
+	Variant  string `json:"variant,omitempty"`
+
+	Filters []jsonFilterOutcome `json:"filters,omitempty"`
+}
+
+type jsonFilterOutcome struct {
+	Filter string `json:"filter"`
+	Kept   bool   `json:"kept"`
 }
 
 // NewSpec starts collecting the tags for "name"
@@ -95,45 +178,179 @@ func (p *JSONPrinter) NewSpec(name string, dur time.Duration, err error) {
 	}
 }
 
-// PrintTag stores the tag with version "other" for the requested "name" which
+// PrintTag stores the tag "other" for the requested "name" which
 // was started via PrintSpec
-func (p *JSONPrinter) PrintTag(name string, base, other *semver.Version) {
+func (p *JSONPrinter) PrintTag(name string, base, other *tag.Tag) {
 
-	if !p.showOld && len(p.cur.Tags) > 0 {
+	if !p.showOld && p.cur.hasCompleteTag() {
 		return
 	}
 
-	// force the "label" part to match base.
-	// if we dont do this, library "semver" considers "-label" to be
-	// a pre-release version and then "1.0.0-label" to be below "1.0.0"
-	// still, we want to print the label later on. so, a copy of "other"
-	// without the pre-release will do.
-	o := *other
-	o, _ = o.SetPrerelease("")
+	jt := newJSONTag(name, base, other)
+	p.cur.Size = base.Size
+
+	if p.cur.Verdict == "" && len(jt.MissingPlatforms) == 0 {
+		p.cur.Verdict = jt.verdict.Invert().String()
+	}
+
+	p.cur.Tags = append(p.cur.Tags, *jt)
+}
+
+// PrintTiers stores the newest update per tier for the requested "name"
+func (p *JSONPrinter) PrintTiers(name string, base *tag.Tag, tiers tag.Tiers) {
 
-	vbase, vother := "", ""
+	p.cur.Size = base.Size
+	p.cur.Patch = newJSONTag(name, base, tiers.Patch)
+	p.cur.Minor = newJSONTag(name, base, tiers.Minor)
+	p.cur.Major = newJSONTag(name, base, tiers.Major)
 
-	// in case, "base" was given as "8.4" … the verdict
-	// should be equal upon 8.4.1 or 8.4.99.
-	bc, _ := semver.NewConstraint(base.Original())
-	if bc.Check(&o) {
-		vbase, vother = "equal", "equal"
-	} else if o.GreaterThan(base) {
-		vbase, vother = "outdated", "ahead"
-	} else if o.Equal(base) {
-		vbase, vother = "equal", "equal"
-	} else if o.LessThan(base) {
-		vbase, vother = "ahead", "outdated"
+	p.cur.Verdict = tag.VerdictEqual.String()
+	if !tiers.Empty() {
+		p.cur.Verdict = tag.VerdictOutdated.String()
 	}
+}
+
+// newJSONTag returns the jsonTag for other; nil if other is nil
+func newJSONTag(name string, base, other *tag.Tag) *jsonTag {
 
-	if p.cur.Verdict == "" {
-		p.cur.Verdict = vbase
+	if other == nil {
+		return nil
 	}
 
-	tag := jsonTag{
+	verdict, kind := tag.Compare(base, other)
+
+	jt := &jsonTag{
 		Name:    name + ":" + other.String(),
-		Version: other.String(),
-		Verdict: vother,
+		Tag:     other.String(),
+		Version: other.Version.String(),
+		Verdict: verdict.String(),
+		Update:  string(kind),
+		Aliases: other.Aliases,
+
+		Platforms:        other.Platforms,
+		MissingPlatforms: other.MissingPlatforms,
+
+		Signature: other.Signature,
+		Referrers: other.Referrers,
+
+		Size: other.Size,
+
+		verdict: verdict,
+	}
+	if other.Size > 0 && base.Size > 0 {
+		delta := other.Size - base.Size
+		jt.SizeDelta = &delta
+	}
+	return jt
+}
+
+// hasCompleteTag reports whether a tag not missing any required platform
+// was stored already
+func (img *jsonImage) hasCompleteTag() bool {
+	for _, t := range img.Tags {
+		if len(t.MissingPlatforms) == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Explain stores the decisions made for the tags of the requested image
+func (p *JSONPrinter) Explain(decisions []tag.Decision) {
+
+	for _, d := range decisions {
+
+		jd := jsonDecision{Tag: d.Name, Kept: d.Kept}
+
+		switch {
+		case !d.NameKept:
+			jd.Reason = "pattern"
+		case d.ParseError != nil:
+			jd.Reason, jd.Error = "parse", d.ParseError.Error()
+		default:
+			jd.Scheme = d.Tag.Scheme.Name()
+			jd.Version = d.Tag.Version.String()
+			jd.Revision = d.Tag.Revision
+			jd.Variant = d.Tag.Variant.String()
+			for _, f := range d.Filters {
+				jd.Filters = append(jd.Filters, jsonFilterOutcome{Filter: f.Name, Kept: f.Kept})
+			}
+			if !d.Kept {
+				jd.Reason = "filter"
+			}
+		}
+
+		p.cur.Decisions = append(p.cur.Decisions, jd)
+	}
+}
+
+// PrintResolved stores which version the requested tag currently stands for
+// and where that information came from
+func (p *JSONPrinter) PrintResolved(requested string, resolved *tag.Tag, source string) {
+	p.cur.Resolved, p.cur.Source = resolved.String(), source
+}
+
+// PrintVerdict stores a verdict about the requested image itself: the tag is
+// missing or was mutated (expected and actual digest differ). It is kept
+// apart from the verdict about the newer tags.
+func (p *JSONPrinter) PrintVerdict(name string, verdict tag.Verdict, expected, actual string) {
+	p.cur.TagState = verdict.String()
+	p.cur.Expected, p.cur.Digest = expected, actual
+}
+
+// PrintDrift stores how far the requested image is behind
+func (p *JSONPrinter) PrintDrift(name string, drift tag.Drift) {
+
+	p.cur.Behind = &jsonDrift{
+		Releases: drift.Releases,
+		Majors:   drift.Majors,
+		Minors:   drift.Minors,
+	}
+	if drift.HasLibyears {
+		p.cur.Behind.Libyears = &drift.Libyears
+	}
+}
+
+// PrintCycle stores the release cycle of the requested image
+func (p *JSONPrinter) PrintCycle(name string, cycle *eol.Cycle, status eol.Status) {
+
+	p.cur.Cycle = &jsonCycle{
+		Cycle:  cycle.Cycle,
+		Status: string(status),
+		EOL:    cycle.EOL.String(),
+		Latest: cycle.Latest,
+	}
+}
+
+// PrintReferrers stores the artifact types attached to the requested image
+func (p *JSONPrinter) PrintReferrers(name string, artifactTypes []string) {
+	p.cur.Referrers = artifactTypes
+}
+
+// PrintBase stores whether the base image of the requested image was
+// updated since it was built, or why that could not be checked
+func (p *JSONPrinter) PrintBase(name string, base *baseimage.Image, err error) {
+
+	if err != nil {
+		p.cur.Base = &jsonBase{Err: err.Error()}
+		return
+	}
+	p.cur.Base = &jsonBase{
+		Name:          base.Name,
+		Digest:        base.Digest,
+		Current:       base.Current,
+		RebuildNeeded: base.Updated(),
+	}
+	if base.Newer != nil {
+		p.cur.Base.Newer = base.Newer.String()
+	}
+}
+
+// PrintVulns stores the vulnerabilities of the requested image per severity
+// and, if it is updatable, the fixable ones as likely fixed by an update
+func (p *JSONPrinter) PrintVulns(name string, summary vuln.Summary, updatable bool) {
+	p.cur.Vulns = &jsonVulns{Counts: summary.Counts, Fixable: summary.Fixable}
+	if updatable {
+		p.cur.Vulns.LikelyFixes = summary.Fixable
 	}
-	p.cur.Tags = append(p.cur.Tags, tag)
 }
@@ -3,9 +3,11 @@ package printer
 import (
 	"time"
 
-	"github.com/Masterminds/semver/v3"
-
+	"github.com/mgumz/cciu/pkg/baseimage"
+	"github.com/mgumz/cciu/pkg/eol"
 	"github.com/mgumz/cciu/pkg/stats"
+	"github.com/mgumz/cciu/pkg/tag"
+	"github.com/mgumz/cciu/pkg/vuln"
 )
 
 // Printer describes the interface for a cciu printer - a helper for controlled
@@ -14,6 +16,15 @@ type Printer interface {
 	SetShowOldTags(bool)
 	SetShowStats(bool)
 	NewSpec(name string, dur time.Duration, err error)
-	PrintTag(name string, base, other *semver.Version)
+	PrintTag(name string, base, other *tag.Tag)
+	PrintTiers(name string, base *tag.Tag, tiers tag.Tiers)
+	Explain(decisions []tag.Decision)
+	PrintResolved(requested string, resolved *tag.Tag, source string)
+	PrintVerdict(name string, verdict tag.Verdict, expected, actual string)
+	PrintDrift(name string, drift tag.Drift)
+	PrintCycle(name string, cycle *eol.Cycle, status eol.Status)
+	PrintReferrers(name string, artifactTypes []string)
+	PrintBase(name string, base *baseimage.Image, err error)
+	PrintVulns(name string, summary vuln.Summary, updatable bool)
 	Flush(stats *stats.AllStats)
 }
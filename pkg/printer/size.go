@@ -0,0 +1,20 @@
+package printer
+
+import (
+	"fmt"
+)
+
+// formatSize formats size (in bytes) with SI units: 52100000 => "52.1 MB"
+func formatSize(size int64) string {
+
+	units := []string{"B", "kB", "MB", "GB", "TB"}
+
+	f, i := float64(size), 0
+	for ; i < len(units)-1 && (f >= 1000 || f <= -1000); i++ {
+		f /= 1000
+	}
+	if i == 0 {
+		return fmt.Sprintf("%d %s", size, units[i])
+	}
+	return fmt.Sprintf("%.1f %s", f, units[i])
+}
@@ -3,24 +3,21 @@ package printer
 import (
 	"fmt"
 	"io"
+	"strings"
 	"text/tabwriter"
 	"time"
 
-	"github.com/Masterminds/semver/v3"
-
+	"github.com/mgumz/cciu/pkg/baseimage"
+	"github.com/mgumz/cciu/pkg/eol"
 	"github.com/mgumz/cciu/pkg/stats"
+	"github.com/mgumz/cciu/pkg/tag"
+	"github.com/mgumz/cciu/pkg/vuln"
 )
 
-const (
-	markNeutral  = 0
-	markAhead    = 1
-	markEqual    = 2
-	markOutdated = 3
-)
-
+// verdict markers, indexed by tag.Verdict
 var (
-	verdictMarkersUnicode = []string{" ", "▲", "=", "▼"}
-	verdictMarkersSimple  = []string{" ", "^", "=", "v"}
+	verdictMarkersUnicode = []string{" ", "▲", "=", "▼", "✗", "≠"}
+	verdictMarkersSimple  = []string{" ", "^", "=", "v", "x", "!"}
 )
 
 // TextPrinter is a small helper to print the requested images, fetched tags
@@ -56,6 +53,41 @@ func (p *TextPrinter) Flush(stats *stats.AllStats) {
 		fmt.Fprintf(p.w, "checked:\t%d\n", stats.Checked)
 		fmt.Fprintf(p.w, "non-semver:\t%d\n", stats.NonSemVer)
 		fmt.Fprintf(p.w, "duplicates:\t%d\n", stats.Duplicates)
+
+		// counts of checks which were not asked for or found nothing are
+		// left out
+		type count struct {
+			name string
+			n    int
+		}
+		counts := []count{
+			{"filtered by pattern", stats.FilteredByPattern},
+			{"missing", stats.Missing},
+			{"mutated", stats.Mutated},
+			{"eol", stats.EOL},
+			{"near eol", stats.NearEOL},
+			{"rebuild needed", stats.RebuildNeeded},
+		}
+		for _, kind := range tag.UpdateKinds {
+			counts = append(counts, count{"update " + string(kind), stats.Updates[string(kind)]})
+		}
+		for _, c := range counts {
+			if c.n > 0 {
+				fmt.Fprintf(p.w, "%s:\t%d\n", c.name, c.n)
+			}
+		}
+		if stats.Vulns != nil {
+			fmt.Fprintf(p.w, "vulnerable:\t%d\n", stats.Vulnerable)
+			for _, severity := range vuln.Severities {
+				fmt.Fprintf(p.w, "vulns %s:\t%d\n", severity, stats.Vulns[severity])
+			}
+		}
+		if stats.Behind.Releases > 0 || stats.Behind.Libyears > 0 {
+			fmt.Fprintf(p.w, "releases behind:\t%d\n", stats.Behind.Releases)
+			fmt.Fprintf(p.w, "majors behind:\t%d\n", stats.Behind.Majors)
+			fmt.Fprintf(p.w, "minors behind:\t%d\n", stats.Behind.Minors)
+			fmt.Fprintf(p.w, "libyears:\t%.2f\n", stats.Behind.Libyears)
+		}
 	}
 	p.w.Flush()
 }
@@ -85,39 +117,245 @@ func (p *TextPrinter) NewSpec(name string, dur time.Duration, err error) {
 	}
 }
 
-// PrintTag prints the tag with version "other" for the requested "name" which
+// PrintTag prints the tag "other" for the requested "name" which
 // was started via PrintSpec.
-func (p *TextPrinter) PrintTag(name string, base, other *semver.Version) {
+func (p *TextPrinter) PrintTag(name string, base, other *tag.Tag) {
 
 	if !p.showOld && p.printedTag {
 		return
 	}
 
-	verdict := " "
+	verdict, kind := tag.Compare(base, other)
+
+	fmt.Fprintf(p.w, "%s    %s:%s\t%s%s%s%s%s%s\n", p.verdictMarkers[verdict], name, other, kind, aliases(other), missingPlatforms(other), signatureState(other), artifacts(other.Referrers), sizeDelta(base, other))
+
+	// tags missing a required platform are only marked, the next tag is
+	// the one to go for
+	p.printedTag = len(other.MissingPlatforms) == 0
+}
+
+// PrintTiers prints the newest update per tier for the requested "name",
+// one labelled row per tier
+func (p *TextPrinter) PrintTiers(name string, base *tag.Tag, tiers tag.Tiers) {
+
+	rows := []struct {
+		label string
+		tag   *tag.Tag
+	}{
+		{"patch", tiers.Patch},
+		{"minor", tiers.Minor},
+		{"major", tiers.Major},
+	}
+
+	for _, row := range rows {
+		if row.tag == nil {
+			continue
+		}
+		verdict, kind := tag.Compare(base, row.tag)
+		fmt.Fprintf(p.w, "%s    %s\t%s:%s\t%s%s%s%s%s%s\n", p.verdictMarkers[verdict], row.label, name, row.tag, kind, aliases(row.tag), missingPlatforms(row.tag), signatureState(row.tag), artifacts(row.tag.Referrers), sizeDelta(base, row.tag))
+	}
+}
+
+// Explain prints the trace of the decisions made for the tags of the
+// requested image, one row per tag: "+" marks kept tags, "-" filtered ones
+func (p *TextPrinter) Explain(decisions []tag.Decision) {
+
+	for _, d := range decisions {
+
+		mark := "-"
+		if d.Kept {
+			mark = "+"
+		}
+
+		switch {
+		case !d.NameKept:
+			fmt.Fprintf(p.w, "    %s %s\tfiltered by pattern\n", mark, d.Name)
+		case d.ParseError != nil:
+			fmt.Fprintf(p.w, "    %s %s\tnot parsed: %s\n", mark, d.Name, d.ParseError)
+		default:
+			outcomes := make([]string, 0, len(d.Filters))
+			for _, f := range d.Filters {
+				outcome := "kept"
+				if !f.Kept {
+					outcome = "filtered"
+				}
+				outcomes = append(outcomes, f.Name+"="+outcome)
+			}
+			fmt.Fprintf(p.w, "    %s %s\t%s\t%s\n", mark, d.Name, describeTag(d.Tag), strings.Join(outcomes, " "))
+		}
+	}
+}
+
+// describeTag returns the components of t as parsed: "semver 1.25.3
+// revision 1 variant alpine3.18"
+func describeTag(t *tag.Tag) string {
+
+	s := t.Scheme.Name() + " " + t.Version.String()
+	if t.Revision > 0 {
+		s += fmt.Sprintf(" revision %d", t.Revision)
+	}
+	if v := t.Variant.String(); v != "" {
+		s += " variant " + v
+	}
+	return s
+}
+
+// aliases returns the aliases of t as an extra column; "" if there are none
+func aliases(t *tag.Tag) string {
+	if len(t.Aliases) == 0 {
+		return ""
+	}
+	return "\taka " + strings.Join(t.Aliases, ", ")
+}
+
+func missingPlatforms(t *tag.Tag) string {
+	switch {
+	case len(t.MissingPlatforms) == 0:
+		return ""
+	case t.Platforms == nil:
+		return "\tplatforms unknown"
+	}
+	return "\tmissing " + strings.Join(t.MissingPlatforms, ", ")
+}
+
+func signatureState(t *tag.Tag) string {
+	if t.Signature == "" {
+		return ""
+	}
+	return "\t" + t.Signature
+}
+
+// artifacts lists the short names of the artifact types; "" if they are
+// unknown (nil)
+func artifacts(types []string) string {
+	switch {
+	case types == nil:
+		return ""
+	case len(types) == 0:
+		return "\tno referrers"
+	}
+	names := make([]string, len(types))
+	for i, t := range types {
+		names[i] = tag.ArtifactName(t)
+	}
+	return "\twith " + strings.Join(names, ", ")
+}
+
+// sizeDelta returns the size of other and its difference to the size of
+// base, if known: "52.1 MB (+12.3 MB)"
+func sizeDelta(base, other *tag.Tag) string {
+	switch {
+	case other.Size == 0:
+		return ""
+	case base.Size == 0:
+		return "\t" + formatSize(other.Size)
+	}
+	delta := formatSize(other.Size - base.Size)
+	if other.Size >= base.Size {
+		delta = "+" + delta
+	}
+	return fmt.Sprintf("\t%s (%s)", formatSize(other.Size), delta)
+}
+
+// PrintResolved prints which version the requested tag ("latest", "3",
+// "edge") currently stands for and where that information came from: the
+// digest or the version label of the image
+func (p *TextPrinter) PrintResolved(requested string, resolved *tag.Tag, source string) {
+	fmt.Fprintf(p.w, "     %s currently = %s\tvia %s%s\n", requested, resolved, source, aliases(resolved))
+}
+
+// PrintVerdict prints a verdict about the requested image "name" itself:
+// the tag is missing or was mutated (expected and actual digest differ)
+func (p *TextPrinter) PrintVerdict(name string, verdict tag.Verdict, expected, actual string) {
+
+	detail := ""
+	if verdict == tag.VerdictMutated {
+		detail = fmt.Sprintf(" %s => %s", shortDigest(expected), shortDigest(actual))
+	}
+	fmt.Fprintf(p.w, "%s    %s\t%s%s\n", p.verdictMarkers[verdict], name, verdict, detail)
+}
+
+// shortDigest shortens "sha256:<hex>" to the first 12 hex digits
+func shortDigest(digest string) string {
+	algo, hex, ok := strings.Cut(digest, ":")
+	if !ok || len(hex) <= 12 {
+		return digest
+	}
+	return algo + ":" + hex[:12]
+}
+
+// PrintDrift prints how far the requested image "name" is behind: newer
+// releases, newer major and minor lines and the libyears, if known
+func (p *TextPrinter) PrintDrift(name string, drift tag.Drift) {
+
+	libyears := ""
+	if drift.HasLibyears {
+		libyears = fmt.Sprintf(", %.2f libyears", drift.Libyears)
+	}
+	fmt.Fprintf(p.w, "     behind %d releases, %d majors, %d minors%s\n", drift.Releases, drift.Majors, drift.Minors, libyears)
+}
+
+// PrintCycle prints the release cycle of the requested image "name" if it
+// reached or nears its end of life
+func (p *TextPrinter) PrintCycle(name string, cycle *eol.Cycle, status eol.Status) {
 
-	// force the "label" part to match base.
-	// if we dont do this, library "semver" considers "-label" to be
-	// a pre-release version and then "1.0.0-label" to be below "1.0.0"
-	// still, we want to print the label later on. so, a copy of "other"
-	// without the pre-release will do.
-	o := *other
-	o, _ = o.SetPrerelease("")
-	b := *base
+	switch status {
+	case eol.StatusEOL:
+		fmt.Fprintf(p.w, "     cycle %s is eol\t%s\n", cycle.Cycle, cycle.EOL)
+	case eol.StatusNearEOL:
+		fmt.Fprintf(p.w, "     cycle %s nears eol\t%s\n", cycle.Cycle, cycle.EOL)
+	}
+}
+
+// PrintReferrers prints the artifact types attached to the requested image
+// "name"
+func (p *TextPrinter) PrintReferrers(name string, artifactTypes []string) {
+	fmt.Fprintf(p.w, "     current%s\n", artifacts(artifactTypes))
+}
+
+// PrintBase prints whether the base image of the requested image "name" was
+// updated since it was built: re-pushed (old => new digest) or superseded by
+// a newer tag. err tells why the base image could not be checked.
+func (p *TextPrinter) PrintBase(name string, base *baseimage.Image, err error) {
+
+	if err != nil {
+		fmt.Fprintf(p.w, "     base unknown\t%s\n", err)
+		return
+	}
+	if !base.Updated() {
+		fmt.Fprintf(p.w, "     base current\t%s\n", base.Name)
+		return
+	}
 
-	// in case, "base" was given as "8.4" … the verdict
-	// should be equal upon 8.4.1 or 8.4.99.
-	bc, _ := semver.NewConstraint(b.Original())
-	if bc.Check(&o) {
-		verdict = p.verdictMarkers[markEqual]
-	} else if o.GreaterThan(&b) {
-		verdict = p.verdictMarkers[markAhead]
-	} else if o.Equal(&b) {
-		verdict = p.verdictMarkers[markEqual]
-	} else if o.LessThan(&b) {
-		verdict = p.verdictMarkers[markOutdated]
+	details := []string{}
+	if base.Repushed() {
+		details = append(details, fmt.Sprintf("%s => %s", shortDigest(base.Digest), shortDigest(base.Current)))
 	}
+	if base.Newer != nil {
+		details = append(details, fmt.Sprintf("newer %s", base.Newer))
+	}
+	fmt.Fprintf(p.w, "     rebuild needed: base updated\t%s %s\n", base.Name, strings.Join(details, ", "))
+}
 
-	fmt.Fprintf(p.w, "%s    %s:%s\t\n", verdict, name, other)
+// PrintVulns prints the vulnerabilities of the requested image "name" per
+// severity, as reported by a scanner, and how many of them are fixable: the
+// ones of OS packages with a fixed version, which an update (if updatable)
+// likely fixes
+func (p *TextPrinter) PrintVulns(name string, summary vuln.Summary, updatable bool) {
 
-	p.printedTag = true
+	counts := []string{}
+	for _, severity := range vuln.Severities {
+		if n := summary.Counts[severity]; n > 0 {
+			counts = append(counts, fmt.Sprintf("%d %s", n, severity))
+		}
+	}
+	if len(counts) == 0 {
+		fmt.Fprintln(p.w, "     no vulns")
+		return
+	}
+	likely := ""
+	if updatable && summary.Fixable > 0 {
+		likely = ", likely by an update"
+	}
+	fmt.Fprintf(p.w, "     vulns %s\t%d fixable%s\n", strings.Join(counts, ", "), summary.Fixable, likely)
 }
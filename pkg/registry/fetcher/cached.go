@@ -0,0 +1,48 @@
+package fetcher
+
+import (
+	"sync"
+	"time"
+
+	"github.com/mgumz/cciu/pkg/registry"
+)
+
+// Cached implements a registry.Fetcher which caches the fetched digests of
+// the wrapped registry.Fetcher: the same image is looked up only once, no
+// matter how often it is asked for
+type Cached struct {
+	registry.Fetcher
+
+	mu      sync.Mutex
+	digests map[string]*cachedDigest
+}
+
+type cachedDigest struct {
+	once   sync.Once
+	digest string
+	dur    time.Duration
+	err    error
+}
+
+// NewCached returns a Cached registry.Fetcher which wraps f
+func NewCached(f registry.Fetcher) *Cached {
+	return &Cached{Fetcher: f, digests: map[string]*cachedDigest{}}
+}
+
+// FetchDigest fetches the manifest digest for name from registry, once
+func (c *Cached) FetchDigest(registry, name string) (string, time.Duration, error) {
+
+	c.mu.Lock()
+	cd, exists := c.digests[name]
+	if !exists {
+		cd = &cachedDigest{}
+		c.digests[name] = cd
+	}
+	c.mu.Unlock()
+
+	cd.once.Do(func() {
+		cd.digest, cd.dur, cd.err = c.Fetcher.FetchDigest(registry, name)
+	})
+
+	return cd.digest, cd.dur, cd.err
+}
@@ -1,12 +1,20 @@
 package fetcher
 
-import "time"
+import (
+	"sync"
+	"time"
+
+	imgspecv1 "github.com/opencontainers/image-spec/specs-go/v1"
+
+	"github.com/mgumz/cciu/pkg/repo"
+)
 
 // PerRegistry implements a registry.Fetcher which allows only a limited amount
 // of concurrent tag-fetch operations per named registry - a rate limiter
 type PerRegistry struct {
 	Simple
 	limit    int
+	mu       sync.Mutex
 	fetchers map[string]chan *Simple
 }
 
@@ -31,7 +39,49 @@ func (pr *PerRegistry) SetAuthFilePath(path string) { pr.authFilePath = path }
 // FetchTags fetches the tags for the repo defined by name in the registry. It
 // will limit the amount of concurrent tag-fetch operations per registry to
 // what was configured via NewPerRegistry
-func (pr *PerRegistry) FetchTags(registry, name string) ([]string, time.Duration, error) {
+func (pr *PerRegistry) FetchTags(registry, name string) (tags []string, dur time.Duration, err error) {
+	pr.withFetcher(registry, func(s *Simple) { tags, dur, err = s.FetchTags(registry, name) })
+	return tags, dur, err
+}
+
+// FetchDigest fetches the manifest digest for the image defined by name in
+// the registry, limited like FetchTags
+func (pr *PerRegistry) FetchDigest(registry, name string) (digest string, dur time.Duration, err error) {
+	pr.withFetcher(registry, func(s *Simple) { digest, dur, err = s.FetchDigest(registry, name) })
+	return digest, dur, err
+}
+
+// FetchImage opens the image defined by name in the registry and calls
+// fetch with it, limited like FetchTags
+func (pr *PerRegistry) FetchImage(registry, name string, fetch repo.ImageFunc) (dur time.Duration, err error) {
+	pr.withFetcher(registry, func(s *Simple) { dur, err = s.FetchImage(registry, name, fetch) })
+	return dur, err
+}
+
+// FetchReferrers fetches the referrers of the image with the given digest in
+// the repo defined by name in the registry, limited like FetchTags
+func (pr *PerRegistry) FetchReferrers(registry, name, digest string) (descs []imgspecv1.Descriptor, dur time.Duration, err error) {
+	pr.withFetcher(registry, func(s *Simple) { descs, dur, err = s.FetchReferrers(registry, name, digest) })
+	return descs, dur, err
+}
+
+// withFetcher calls fetch with a fetcher of the pool of registry, waiting
+// for one to become available
+func (pr *PerRegistry) withFetcher(registry string, fetch func(s *Simple)) {
+
+	fetchers := pr.registryFetchers(registry)
+
+	simple := <-fetchers
+	defer func() { fetchers <- simple }()
+
+	fetch(simple)
+}
+
+// registryFetchers returns the pool of fetchers for registry
+func (pr *PerRegistry) registryFetchers(registry string) chan *Simple {
+
+	pr.mu.Lock()
+	defer pr.mu.Unlock()
 
 	fetchers, exists := pr.fetchers[registry]
 	if !exists {
@@ -44,10 +94,5 @@ func (pr *PerRegistry) FetchTags(registry, name string) ([]string, time.Duration
 		}
 		pr.fetchers[registry] = fetchers
 	}
-
-	simple := <-fetchers
-	tags, dur, err := simple.FetchTags(registry, name)
-	fetchers <- simple
-
-	return tags, dur, err
+	return fetchers
 }
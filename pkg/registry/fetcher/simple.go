@@ -3,6 +3,8 @@ package fetcher
 import (
 	"time"
 
+	imgspecv1 "github.com/opencontainers/image-spec/specs-go/v1"
+
 	"github.com/mgumz/cciu/pkg/repo"
 )
 
@@ -27,3 +29,23 @@ func (s *Simple) SetAuthFilePath(path string) { s.authFilePath = path }
 func (s *Simple) FetchTags(registry, name string) ([]string, time.Duration, error) {
 	return repo.FetchTags(name, s.timeout, s.authFilePath)
 }
+
+// FetchDigest fetches the manifest digest for name from registry. name is a
+// full specified container name which includes the registry part and the tag.
+func (s *Simple) FetchDigest(registry, name string) (string, time.Duration, error) {
+	return repo.FetchDigest(name, s.timeout, s.authFilePath)
+}
+
+// FetchImage opens the image name from registry and calls fetch with it.
+// name is a full specified container name which includes the registry part
+// and the tag or digest.
+func (s *Simple) FetchImage(registry, name string, fetch repo.ImageFunc) (time.Duration, error) {
+	return repo.FetchImage(name, s.timeout, s.authFilePath, fetch)
+}
+
+// FetchReferrers fetches the referrers of the image with the given digest in
+// the repo name from registry. name is a full specified container name which
+// includes the registry part.
+func (s *Simple) FetchReferrers(registry, name, digest string) ([]imgspecv1.Descriptor, time.Duration, error) {
+	return repo.FetchReferrers(name, digest, s.timeout, s.authFilePath)
+}
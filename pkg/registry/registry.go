@@ -1,12 +1,31 @@
 package registry
 
-import "time"
+import (
+	"time"
+
+	imgspecv1 "github.com/opencontainers/image-spec/specs-go/v1"
+
+	"github.com/mgumz/cciu/pkg/repo"
+)
 
 // Fetcher defines the interface for a Registry.Fetcher to provide various
 // ways to fetch the tags for a given name from different registries
 type Fetcher interface {
 	FetchTags(registry, name string) ([]string, time.Duration, error)
 
+	// FetchDigest fetches the manifest digest of the image given by name,
+	// including its tag
+	FetchDigest(registry, name string) (string, time.Duration, error)
+
+	// FetchImage opens the image given by name, including its tag or
+	// digest, and calls fetch to fetch its config, annotations, platforms,
+	// … (see repo.Image)
+	FetchImage(registry, name string, fetch repo.ImageFunc) (time.Duration, error)
+
+	// FetchReferrers fetches the descriptors of the OCI 1.1 referrers of the
+	// image with the given digest in the repo given by name
+	FetchReferrers(registry, name, digest string) ([]imgspecv1.Descriptor, time.Duration, error)
+
 	// SetTimeout defines the timeout for fetch operations
 	SetTimeout(timeout time.Duration)
 
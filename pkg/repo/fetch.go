@@ -1,11 +1,9 @@
 package repo
 
 import (
-	"context"
 	"time"
 
 	"github.com/containers/image/v5/docker"
-	"github.com/containers/image/v5/types"
 )
 
 // FetchTags fetches the tags for the given repo as identified by name
@@ -16,17 +14,29 @@ func FetchTags(name string, timeout time.Duration, authFilePath string) ([]strin
 		return []string{}, time.Duration(0), err
 	}
 
-	ctx, cancel := context.Background(), func() {}
-	if timeout > 0 {
-		ctx, cancel = context.WithTimeout(context.Background(), timeout)
-	}
+	ctx, cancel := newContext(timeout)
 	defer cancel()
 
-	sys := types.SystemContext{}
-	// TODO: use authFilePath in some form to log into the registry
-
 	ts := time.Now()
-	tags, err := docker.GetRepositoryTags(ctx, &sys, ref)
+	tags, err := docker.GetRepositoryTags(ctx, newSystemContext(authFilePath), ref)
 
 	return tags, time.Since(ts), err
 }
+
+// FetchDigest fetches the manifest digest for the given image as identified
+// by name, including its tag
+func FetchDigest(name string, timeout time.Duration, authFilePath string) (string, time.Duration, error) {
+
+	ref, err := docker.ParseReference("//" + name)
+	if err != nil {
+		return "", time.Duration(0), err
+	}
+
+	ctx, cancel := newContext(timeout)
+	defer cancel()
+
+	ts := time.Now()
+	digest, err := docker.GetDigest(ctx, newSystemContext(authFilePath), ref)
+
+	return digest.String(), time.Since(ts), err
+}
@@ -0,0 +1,150 @@
+package repo
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/containers/image/v5/manifest"
+	"github.com/containers/image/v5/pkg/blobinfocache/none"
+	"github.com/containers/image/v5/types"
+	imgspecv1 "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// Config fetches the config of img. For multi-platform images, the config
+// of the image for the current platform is fetched.
+func (img *Image) Config() (*imgspecv1.Image, error) {
+
+	m, err := img.manifest(img.sys)
+	if err != nil {
+		return nil, err
+	}
+	return img.config(m)
+}
+
+// config fetches the config of the image of the manifest m
+func (img *Image) config(m manifest.Manifest) (*imgspecv1.Image, error) {
+
+	blob, _, err := img.src.GetBlob(img.ctx, m.ConfigInfo(), none.NoCache)
+	if err != nil {
+		return nil, err
+	}
+	defer blob.Close()
+
+	config := &imgspecv1.Image{}
+	return config, json.NewDecoder(blob).Decode(config)
+}
+
+// manifest fetches the manifest of img. For manifest lists the manifest of
+// the image matching the platform of sys is fetched.
+func (img *Image) manifest(sys *types.SystemContext) (manifest.Manifest, error) {
+
+	blob, mimeType, err := img.src.GetManifest(img.ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if manifest.MIMETypeIsMultiImage(mimeType) {
+		list, err := manifest.ListFromBlob(blob, mimeType)
+		if err != nil {
+			return nil, err
+		}
+		instance, err := list.ChooseInstance(sys)
+		if err != nil {
+			return nil, err
+		}
+		if blob, mimeType, err = img.src.GetManifest(img.ctx, &instance); err != nil {
+			return nil, err
+		}
+	}
+
+	return manifest.FromBlob(blob, mimeType)
+}
+
+// Annotations fetches the annotations of img: the ones of the manifest
+// list / OCI index, of the manifest (for the current platform) and the
+// labels of the config. Annotations of the manifests take precedence over
+// the labels.
+func (img *Image) Annotations() (map[string]string, error) {
+
+	annotations := map[string]string{}
+
+	blob, mimeType, err := img.src.GetManifest(img.ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	if manifest.MIMETypeIsMultiImage(mimeType) {
+		if err := addAnnotations(annotations, blob); err != nil {
+			return nil, err
+		}
+	}
+
+	m, err := img.manifest(img.sys)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := m.Serialize()
+	if err != nil {
+		return nil, err
+	}
+	if err := addAnnotations(annotations, raw); err != nil {
+		return nil, err
+	}
+
+	config, err := img.config(m)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range config.Config.Labels {
+		if _, exists := annotations[k]; !exists {
+			annotations[k] = v
+		}
+	}
+
+	return annotations, nil
+}
+
+// addAnnotations adds the annotations of the manifest or index in blob to
+// annotations
+func addAnnotations(annotations map[string]string, blob []byte) error {
+
+	m := struct {
+		Annotations map[string]string `json:"annotations"`
+	}{}
+	if err := json.Unmarshal(blob, &m); err != nil {
+		return err
+	}
+	for k, v := range m.Annotations {
+		annotations[k] = v
+	}
+	return nil
+}
+
+// Size fetches the compressed size of img: the sum of the sizes of its
+// layers. For multi-platform images, the image for platform
+// ("linux/arm64/v8") is measured; for the current platform if platform is
+// "".
+func (img *Image) Size(platform string) (int64, error) {
+
+	sys := *img.sys
+	if platform != "" {
+		parts := strings.SplitN(platform, "/", 3)
+		sys.OSChoice = parts[0]
+		if len(parts) > 1 {
+			sys.ArchitectureChoice = parts[1]
+		}
+		if len(parts) > 2 {
+			sys.VariantChoice = parts[2]
+		}
+	}
+
+	m, err := img.manifest(&sys)
+	if err != nil {
+		return 0, err
+	}
+
+	size := int64(0)
+	for _, layer := range m.LayerInfos() {
+		size += layer.Size
+	}
+	return size, nil
+}
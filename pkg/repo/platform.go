@@ -0,0 +1,57 @@
+package repo
+
+import (
+	"github.com/containers/image/v5/manifest"
+	imgspecv1 "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// Platforms fetches the platforms ("linux/arm64/v8") img is available for:
+// the platforms of the entries of a manifest list or OCI index, the platform
+// of the config for single images. Attestations ("unknown/unknown") are
+// skipped.
+func (img *Image) Platforms() ([]string, error) {
+
+	blob, mimeType, err := img.src.GetManifest(img.ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if manifest.MIMETypeIsMultiImage(mimeType) {
+		list, err := manifest.ListFromBlob(blob, mimeType)
+		if err != nil {
+			return nil, err
+		}
+		platforms := []string{}
+		for _, d := range list.Instances() {
+			instance, err := list.Instance(d)
+			if err != nil {
+				return nil, err
+			}
+			if p := instance.ReadOnly.Platform; p != nil && p.OS != "unknown" {
+				platforms = append(platforms, platformString(p))
+			}
+		}
+		return platforms, nil
+	}
+
+	m, err := manifest.FromBlob(blob, mimeType)
+	if err != nil {
+		return nil, err
+	}
+	config, err := img.config(m)
+	if err != nil {
+		return nil, err
+	}
+
+	return []string{platformString(&config.Platform)}, nil
+}
+
+// platformString returns "os/arch" or "os/arch/variant"
+func platformString(p *imgspecv1.Platform) string {
+
+	s := p.OS + "/" + p.Architecture
+	if p.Variant != "" {
+		s += "/" + p.Variant
+	}
+	return s
+}
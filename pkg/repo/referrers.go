@@ -0,0 +1,186 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/containers/image/v5/docker"
+	"github.com/containers/image/v5/docker/reference"
+	"github.com/containers/image/v5/pkg/sysregistriesv2"
+	"github.com/containers/image/v5/types"
+	"github.com/docker/distribution/registry/api/errcode"
+	v2 "github.com/docker/distribution/registry/api/v2"
+	imgspecv1 "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// maxReferrersPages limits the number of pages of the referrers API to
+// follow
+const maxReferrersPages = 10
+
+// maxIndexSize limits the size of a referrers index to read
+const maxIndexSize = 4 << 20
+
+// errReferrersUnsupported is returned by registries without the referrers
+// API
+var errReferrersUnsupported = errors.New("referrers API not supported")
+
+// FetchReferrers fetches the descriptors of the OCI 1.1 referrers (SBOMs,
+// attestations, signatures) of the image with the given digest in the repo
+// given by name. Registries without the referrers API are asked via the
+// referrers tag schema ("app:sha256-abc…").
+func FetchReferrers(name, digest string, timeout time.Duration, authFilePath string) ([]imgspecv1.Descriptor, time.Duration, error) {
+
+	ref, err := docker.ParseReference("//" + name)
+	if err != nil {
+		return nil, time.Duration(0), err
+	}
+
+	ctx, cancel := newContext(timeout)
+	defer cancel()
+
+	sys := newSystemContext(authFilePath)
+
+	ts := time.Now()
+
+	named := ref.DockerReference()
+	descs, err := fetchReferrersAPI(ctx, sys, named, digest)
+	if errors.Is(err, errReferrersUnsupported) {
+		descs, err = fetchReferrersTag(named.Name()+":"+strings.Replace(digest, ":", "-", 1), timeout, authFilePath)
+	}
+
+	return descs, time.Since(ts), err
+}
+
+// fetchReferrersAPI asks the referrers API of the endpoints of the registry
+// of named, mirrors first, until one answers
+func fetchReferrersAPI(ctx context.Context, sys *types.SystemContext, named reference.Named, digest string) ([]imgspecv1.Descriptor, error) {
+
+	sources, err := pullSources(sys, named)
+	if err != nil {
+		return nil, err
+	}
+
+	err = errReferrersUnsupported
+	for _, source := range sources {
+		var descs []imgspecv1.Descriptor
+		if descs, err = fetchReferrersFrom(ctx, sys, source, digest); err == nil {
+			return descs, nil
+		}
+	}
+	return nil, err
+}
+
+// fetchReferrersFrom asks the referrers API of the endpoint of source,
+// following the pages of the result
+func fetchReferrersFrom(ctx context.Context, sys *types.SystemContext, source sysregistriesv2.PullSource, digest string) ([]imgspecv1.Descriptor, error) {
+
+	c, err := newRegistryClient(sys, source)
+	if err != nil {
+		return nil, err
+	}
+	defer c.close()
+
+	descs := []imgspecv1.Descriptor{}
+	api := "/v2/" + reference.Path(source.Reference) + "/referrers/" + digest
+
+	for page := 0; api != "" && page < maxReferrersPages; page++ {
+
+		resp, err := c.get(ctx, api, imgspecv1.MediaTypeImageIndex)
+		if err != nil {
+			return nil, err
+		}
+
+		index, err := decodeIndex(resp)
+		if err != nil {
+			return nil, err
+		}
+		descs = append(descs, index.Manifests...)
+
+		api = nextPage(resp)
+	}
+
+	return descs, nil
+}
+
+// decodeIndex decodes the referrers index of resp and closes its body
+func decodeIndex(resp *http.Response) (*imgspecv1.Index, error) {
+
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, errReferrersUnsupported
+	default:
+		return nil, fmt.Errorf("referrers API: %s", resp.Status)
+	}
+
+	index := &imgspecv1.Index{}
+	err := json.NewDecoder(io.LimitReader(resp.Body, maxIndexSize)).Decode(index)
+	return index, err
+}
+
+// reLinkNext matches the link to the next page: `<url>; rel="next"`
+var reLinkNext = regexp.MustCompile(`<([^>]+)>;\s*rel="?next"?`)
+
+// nextPage returns the link to the next page of the result of resp; "" if
+// there is none
+func nextPage(resp *http.Response) string {
+
+	m := reLinkNext.FindStringSubmatch(resp.Header.Get("Link"))
+	if m == nil {
+		return ""
+	}
+	return m[1]
+}
+
+// fetchReferrersTag fetches the referrers index stored under the referrers
+// tag schema as given by name; no referrers if the tag does not exist
+func fetchReferrersTag(name string, timeout time.Duration, authFilePath string) ([]imgspecv1.Descriptor, error) {
+
+	descs := []imgspecv1.Descriptor{}
+	_, err := FetchImage(name, timeout, authFilePath, func(img *Image) (err error) {
+		descs, err = img.Index()
+		return err
+	})
+	if isManifestUnknown(err) {
+		return []imgspecv1.Descriptor{}, nil
+	}
+	return descs, err
+}
+
+// Index fetches the manifest of img as OCI index and returns its entries:
+// the referrers stored under the referrers tag schema
+func (img *Image) Index() ([]imgspecv1.Descriptor, error) {
+
+	blob, _, err := img.src.GetManifest(img.ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	index := &imgspecv1.Index{}
+	if err := json.Unmarshal(blob, index); err != nil {
+		return nil, err
+	}
+	return index.Manifests, nil
+}
+
+// isManifestUnknown reports whether err tells about a missing manifest,
+// either by its error code or, as some registries (Harbor, Quay) do, by its
+// message
+func isManifestUnknown(err error) bool {
+
+	var ec errcode.ErrorCoder
+	if errors.As(err, &ec) && ec.ErrorCode() == v2.ErrorCodeManifestUnknown {
+		return true
+	}
+	var e errcode.Error
+	return errors.As(err, &e) && e.ErrorCode() == errcode.ErrorCodeUnknown && strings.Contains(strings.ToLower(e.Message), "not found")
+}
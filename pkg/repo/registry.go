@@ -0,0 +1,254 @@
+package repo
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/containers/image/v5/docker/reference"
+	"github.com/containers/image/v5/pkg/docker/config"
+	"github.com/containers/image/v5/pkg/sysregistriesv2"
+	"github.com/containers/image/v5/pkg/tlsclientconfig"
+	"github.com/containers/image/v5/types"
+	"github.com/docker/distribution/registry/client/auth/challenge"
+)
+
+// dockerHostname is the host of the Docker Hub in image references,
+// dockerRegistry the host serving its API
+const (
+	dockerHostname = "docker.io"
+	dockerRegistry = "registry-1.docker.io"
+)
+
+// maxTokenSize limits the size of a token response to read
+const maxTokenSize = 1 << 20
+
+// certDirs are the directories holding the certificates per host, as read
+// by containers/image
+var certDirs = []string{"/etc/containers/certs.d", "/etc/docker/certs.d"}
+
+// registryClient requests the API of a registry endpoint, for registry API
+// calls containers/image does not offer (the referrers API). It uses the
+// same settings as containers/image (see newSystemContext): mirrors and
+// insecure registries of the registries.conf, the certificates of the host
+// and the credentials of the credential store.
+type registryClient struct {
+	client   *http.Client
+	scheme   string
+	host     string
+	insecure bool
+	auth     types.DockerAuthConfig
+	scope    string
+	basic    bool
+	token    string
+}
+
+// pullSources returns the endpoints to ask for the repo named, mirrors first,
+// as configured in the registries.conf
+func pullSources(sys *types.SystemContext, named reference.Named) ([]sysregistriesv2.PullSource, error) {
+
+	reg, err := sysregistriesv2.FindRegistry(sys, named.Name())
+	if err != nil {
+		return nil, err
+	}
+	if reg == nil {
+		return []sysregistriesv2.PullSource{{
+			Endpoint:  sysregistriesv2.Endpoint{Location: reference.Domain(named)},
+			Reference: named,
+		}}, nil
+	}
+	if reg.Blocked {
+		return nil, fmt.Errorf("registry %s is blocked", reg.Prefix)
+	}
+	return reg.PullSourcesFromReference(named)
+}
+
+// newRegistryClient returns a registryClient for the endpoint of source,
+// allowed to pull from its repo
+func newRegistryClient(sys *types.SystemContext, source sysregistriesv2.PullSource) (*registryClient, error) {
+
+	auth, err := config.GetCredentialsForRef(sys, source.Reference)
+	if err != nil {
+		return nil, err
+	}
+
+	host := reference.Domain(source.Reference)
+	tlsc := &tls.Config{InsecureSkipVerify: source.Endpoint.Insecure} // #nosec G402 insecure registries as configured
+	if err := tlsclientconfig.SetupCertificates(certDir(sys, host), tlsc); err != nil {
+		return nil, err
+	}
+	transport := tlsclientconfig.NewTransport()
+	transport.TLSClientConfig = tlsc
+
+	if host == dockerHostname {
+		host = dockerRegistry
+	}
+
+	return &registryClient{
+		client:   &http.Client{Transport: transport},
+		scheme:   "https",
+		host:     host,
+		insecure: source.Endpoint.Insecure,
+		auth:     auth,
+		scope:    "repository:" + reference.Path(source.Reference) + ":pull",
+	}, nil
+}
+
+// certDir returns the directory holding the certificates for host
+func certDir(sys *types.SystemContext, host string) string {
+
+	if sys.DockerCertPath != "" {
+		return sys.DockerCertPath
+	}
+	dirs := certDirs
+	if home, err := os.UserHomeDir(); err == nil {
+		dirs = append([]string{filepath.Join(home, ".config/containers/certs.d")}, dirs...)
+	}
+	for _, dir := range dirs {
+		if _, err := os.Stat(filepath.Join(dir, host)); err == nil {
+			return filepath.Join(dir, host)
+		}
+	}
+	return ""
+}
+
+// get requests the resource at ref, relative to the endpoint, accepting
+// the media type accept. If the registry demands it, c authenticates and
+// tries again.
+func (c *registryClient) get(ctx context.Context, ref, accept string) (*http.Response, error) {
+
+	resp, err := c.do(ctx, ref, accept)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized || c.basic || c.token != "" {
+		return resp, err
+	}
+
+	challenges := challenge.ResponseChallenges(resp)
+	resp.Body.Close()
+	if err := c.authenticate(ctx, challenges); err != nil {
+		return nil, err
+	}
+	return c.do(ctx, ref, accept)
+}
+
+// do sends a single request for ref; insecure registries are asked via
+// http, if https fails
+func (c *registryClient) do(ctx context.Context, ref, accept string) (*http.Response, error) {
+
+	base := &url.URL{Scheme: c.scheme, Host: c.host}
+	u, err := base.Parse(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", accept)
+	switch {
+	case c.token != "":
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	case c.basic:
+		req.SetBasicAuth(c.auth.Username, c.auth.Password)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil && c.insecure && c.scheme == "https" && u.Host == c.host {
+		c.scheme = "http"
+		return c.do(ctx, ref, accept)
+	}
+	return resp, err
+}
+
+// authenticate answers the challenges of the registry: with the
+// credentials (basic) or with a bearer token fetched for them
+func (c *registryClient) authenticate(ctx context.Context, challenges []challenge.Challenge) error {
+
+	for _, ch := range challenges {
+		switch {
+		case strings.EqualFold(ch.Scheme, "bearer"):
+			token, err := c.fetchToken(ctx, ch.Parameters)
+			c.token = token
+			return err
+		case strings.EqualFold(ch.Scheme, "basic") && c.auth.Username != "":
+			c.basic = true
+			return nil
+		}
+	}
+	return errors.New("registry: unauthorized")
+}
+
+// fetchToken fetches a bearer token to pull from the repo of c from the
+// realm given by params: with the identity token (OAuth2), the username and
+// password of c or anonymously
+func (c *registryClient) fetchToken(ctx context.Context, params map[string]string) (string, error) {
+
+	realm, err := url.Parse(params["realm"])
+	if err != nil || params["realm"] == "" {
+		return "", fmt.Errorf("registry: invalid realm %q", params["realm"])
+	}
+
+	q := url.Values{}
+	if params["service"] != "" {
+		q.Set("service", params["service"])
+	}
+	q.Set("scope", c.scope)
+
+	var req *http.Request
+	if c.auth.IdentityToken != "" {
+		q.Set("grant_type", "refresh_token")
+		q.Set("refresh_token", c.auth.IdentityToken)
+		q.Set("client_id", "cciu")
+		req, err = http.NewRequestWithContext(ctx, http.MethodPost, realm.String(), strings.NewReader(q.Encode()))
+		if err != nil {
+			return "", err
+		}
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	} else {
+		if c.auth.Username != "" {
+			q.Set("account", c.auth.Username)
+		}
+		realm.RawQuery = q.Encode()
+		req, err = http.NewRequestWithContext(ctx, http.MethodGet, realm.String(), nil)
+		if err != nil {
+			return "", err
+		}
+		if c.auth.Username != "" && c.auth.Password != "" {
+			req.SetBasicAuth(c.auth.Username, c.auth.Password)
+		}
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("registry: fetching token: %s", resp.Status)
+	}
+
+	tokens := struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}{}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxTokenSize)).Decode(&tokens); err != nil {
+		return "", err
+	}
+	if tokens.Token != "" {
+		return tokens.Token, nil
+	}
+	return tokens.AccessToken, nil
+}
+
+// close releases the connections of c
+func (c *registryClient) close() {
+	c.client.CloseIdleConnections()
+}
@@ -1 +1,62 @@
 package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/containers/image/v5/docker"
+	"github.com/containers/image/v5/types"
+)
+
+// Image is an image of a registry, opened to fetch its manifests, its
+// config and its blobs
+type Image struct {
+	ctx context.Context
+	sys *types.SystemContext
+	src types.ImageSource
+}
+
+// ImageFunc fetches whatever is needed from the opened image img
+type ImageFunc func(img *Image) error
+
+// FetchImage opens the image as identified by name, including its tag or
+// digest, and calls fetch with it
+func FetchImage(name string, timeout time.Duration, authFilePath string, fetch ImageFunc) (time.Duration, error) {
+
+	ref, err := docker.ParseReference("//" + name)
+	if err != nil {
+		return time.Duration(0), err
+	}
+
+	ctx, cancel := newContext(timeout)
+	defer cancel()
+
+	sys := newSystemContext(authFilePath)
+
+	ts := time.Now()
+
+	src, err := ref.NewImageSource(ctx, sys)
+	if err != nil {
+		return time.Since(ts), err
+	}
+	defer src.Close()
+
+	err = fetch(&Image{ctx: ctx, sys: sys, src: src})
+
+	return time.Since(ts), err
+}
+
+// newSystemContext returns the settings to access registries: besides the
+// given credential store, containers/image reads the registries.conf
+// (mirrors, insecure registries) and the certificates of the host
+func newSystemContext(authFilePath string) *types.SystemContext {
+	return &types.SystemContext{AuthFilePath: authFilePath}
+}
+
+func newContext(timeout time.Duration) (context.Context, context.CancelFunc) {
+
+	if timeout > 0 {
+		return context.WithTimeout(context.Background(), timeout)
+	}
+	return context.Background(), func() {}
+}
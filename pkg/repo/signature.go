@@ -0,0 +1,75 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+
+	"github.com/containers/image/v5/pkg/blobinfocache/none"
+	"github.com/containers/image/v5/types"
+	imgspecv1 "github.com/opencontainers/image-spec/specs-go/v1"
+
+	"github.com/mgumz/cciu/pkg/signature"
+)
+
+// maxPayloadSize limits the size of a signature payload to read
+const maxPayloadSize = 1 << 20
+
+// Signatures fetches the cosign signatures of img, a signature manifest
+// given either by its tag ("app:sha256-abc….sig") or, for OCI 1.1
+// referrers, by its digest ("app@sha256:…"). Referrers may be sigstore
+// bundles as well.
+func (img *Image) Signatures() ([]signature.Signature, error) {
+
+	m, err := fetchSigManifest(img.ctx, img.src)
+	if err != nil {
+		return nil, err
+	}
+	return fetchSigLayers(img.ctx, img.src, m)
+}
+
+func fetchSigManifest(ctx context.Context, src types.ImageSource) (*imgspecv1.Manifest, error) {
+
+	blob, _, err := src.GetManifest(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	m := &imgspecv1.Manifest{}
+	return m, json.Unmarshal(blob, m)
+}
+
+// fetchSigLayers fetches the payloads of the layers of m carrying a cosign
+// signature or a sigstore bundle
+func fetchSigLayers(ctx context.Context, src types.ImageSource, m *imgspecv1.Manifest) ([]signature.Signature, error) {
+
+	sigs := []signature.Signature{}
+	for _, layer := range m.Layers {
+
+		sig, ok := layer.Annotations[signature.Annotation]
+		bundle := signature.IsBundle(layer.MediaType)
+		if !ok && !bundle {
+			continue
+		}
+
+		blob, _, err := src.GetBlob(ctx, types.BlobInfo{Digest: layer.Digest, Size: layer.Size}, none.NoCache)
+		if err != nil {
+			return nil, err
+		}
+		payload, err := io.ReadAll(io.LimitReader(blob, maxPayloadSize))
+		blob.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		if !bundle {
+			sigs = append(sigs, signature.Signature{Payload: payload, Signature: sig})
+			continue
+		}
+		found, err := signature.ParseBundle(payload)
+		if err != nil {
+			return nil, err
+		}
+		sigs = append(sigs, found...)
+	}
+	return sigs, nil
+}
@@ -0,0 +1,123 @@
+package signature
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// BundleArtifactType is the artifact type (and the media type of the layer)
+// of sigstore bundles stored as OCI 1.1 referrers, as cosign attaches them
+// with "--new-bundle-format"
+const BundleArtifactType = "application/vnd.dev.sigstore.bundle.v0.3+json"
+
+// bundleMediaTypePrefix is shared by all versions of sigstore bundles
+const bundleMediaTypePrefix = "application/vnd.dev.sigstore.bundle"
+
+// IsArtifactType reports whether t is the artifact type of a cosign
+// signature or a sigstore bundle
+func IsArtifactType(t string) bool {
+	return t == ArtifactType || IsBundle(t)
+}
+
+// IsBundle reports whether t is the (media or artifact) type of a sigstore
+// bundle, of any version
+func IsBundle(t string) bool {
+	return strings.HasPrefix(t, bundleMediaTypePrefix)
+}
+
+// bundle is the part of a sigstore bundle holding the signature: either an
+// in-toto statement in a DSSE envelope or a signature of the digest
+type bundle struct {
+	DSSEEnvelope *struct {
+		Payload     string `json:"payload"`
+		PayloadType string `json:"payloadType"`
+		Signatures  []struct {
+			Sig string `json:"sig"`
+		} `json:"signatures"`
+	} `json:"dsseEnvelope"`
+	MessageSignature *struct {
+		MessageDigest struct {
+			Algorithm string `json:"algorithm"`
+			Digest    string `json:"digest"`
+		} `json:"messageDigest"`
+		Signature string `json:"signature"`
+	} `json:"messageSignature"`
+}
+
+// statement is the part of an in-toto statement binding it to an image
+type statement struct {
+	Subject []struct {
+		Digest map[string]string `json:"digest"`
+	} `json:"subject"`
+}
+
+// ParseBundle returns the signatures of the sigstore bundle in data
+func ParseBundle(data []byte) ([]Signature, error) {
+
+	b := bundle{}
+	if err := json.Unmarshal(data, &b); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case b.DSSEEnvelope != nil:
+		payload, err := base64.StdEncoding.DecodeString(b.DSSEEnvelope.Payload)
+		if err != nil {
+			return nil, err
+		}
+		sigs := []Signature{}
+		for _, s := range b.DSSEEnvelope.Signatures {
+			sigs = append(sigs, Signature{
+				Format:      FormatDSSE,
+				Payload:     payload,
+				PayloadType: b.DSSEEnvelope.PayloadType,
+				Signature:   s.Sig,
+			})
+		}
+		return sigs, nil
+
+	case b.MessageSignature != nil:
+		md := b.MessageSignature.MessageDigest
+		if md.Algorithm != "SHA2_256" {
+			return nil, fmt.Errorf("unsupported digest algorithm %q", md.Algorithm)
+		}
+		raw, err := base64.StdEncoding.DecodeString(md.Digest)
+		if err != nil {
+			return nil, err
+		}
+		return []Signature{{
+			Format:    FormatDigest,
+			Payload:   []byte("sha256:" + hex.EncodeToString(raw)),
+			Signature: b.MessageSignature.Signature,
+		}}, nil
+	}
+
+	return nil, errors.New("bundle without signature")
+}
+
+// pae returns the DSSE pre-authentication encoding of payload, the signed
+// message of a DSSE envelope
+func pae(payloadType string, payload []byte) []byte {
+	return []byte(fmt.Sprintf("DSSEv1 %d %s %d %s", len(payloadType), payloadType, len(payload), payload))
+}
+
+// statementNames reports whether the in-toto statement in payload has the
+// image with the given digest as subject
+func statementNames(payload []byte, digest string) bool {
+
+	s := statement{}
+	if err := json.Unmarshal(payload, &s); err != nil {
+		return false
+	}
+	algo, hexDigest, _ := strings.Cut(digest, ":")
+	for _, subject := range s.Subject {
+		if subject.Digest[algo] == hexDigest {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,164 @@
+package signature
+
+import (
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Status tells whether an image is signed
+type Status string
+
+// The states of an image, according to its signatures
+const (
+	StatusSigned   Status = "signed"
+	StatusUnsigned Status = "unsigned"
+	StatusOtherKey Status = "other-key"
+	StatusInvalid  Status = "invalid"
+)
+
+// Annotation is the annotation of a layer of a cosign signature manifest
+// holding the base64 encoded signature of the layer (the payload)
+const Annotation = "dev.cosignproject.cosign/signature"
+
+// ArtifactType is the artifact type of cosign signatures stored as OCI 1.1
+// referrers
+const ArtifactType = "application/vnd.dev.cosign.artifact.sig.v1+json"
+
+// The formats of a Signature
+const (
+	FormatSimpleSigning = ""       // cosign "simple signing" JSON
+	FormatDSSE          = "dsse"   // in-toto statement of a sigstore bundle
+	FormatDigest        = "digest" // digest of the image, of a sigstore bundle
+)
+
+// Signature is a cosign signature: the signed payload in the given format
+// and the base64 encoded signature of it. DSSE payloads are signed along
+// with their PayloadType, digests ("sha256:abc…") as they are.
+type Signature struct {
+	Format      string
+	Payload     []byte
+	PayloadType string
+	Signature   string
+}
+
+// Tag returns the tag cosign stores the signatures of the image with the
+// given digest under: "sha256:abc…" => "sha256-abc….sig"
+func Tag(digest string) string {
+	return strings.Replace(digest, ":", "-", 1) + ".sig"
+}
+
+// payload is the part of the "simple signing" payload binding the signature
+// to an image
+type payload struct {
+	Critical struct {
+		Image struct {
+			Digest string `json:"docker-manifest-digest"`
+		} `json:"image"`
+	} `json:"critical"`
+}
+
+// Verifier verifies cosign signatures against a public key, offline
+type Verifier struct {
+	key *ecdsa.PublicKey
+}
+
+// LoadPublicKey reads the PEM encoded ECDSA public key ("cosign.pub") at
+// path
+func LoadPublicKey(path string) (*Verifier, error) {
+
+	data, err := os.ReadFile(path) // #nosec G304
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM encoded key found")
+	}
+
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	ecKey, ok := key.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("unsupported key type %T, expected ECDSA", key)
+	}
+	return &Verifier{key: ecKey}, nil
+}
+
+// NewVerifier returns a Verifier for key
+func NewVerifier(key *ecdsa.PublicKey) *Verifier {
+	return &Verifier{key: key}
+}
+
+// Verify checks the signatures of the image with the given digest: the
+// image is signed if one of them is made with the key of v and its payload
+// names the digest. Images without signatures are unsigned, images whose
+// signatures are made with other keys (or keyless) are signed by another
+// key, images with a signature of v for another digest are invalid.
+func (v *Verifier) Verify(sigs []Signature, digest string) Status {
+
+	if len(sigs) == 0 {
+		return StatusUnsigned
+	}
+	status := StatusOtherKey
+	for _, sig := range sigs {
+		switch s := v.verify(sig, digest); s {
+		case StatusSigned:
+			return s
+		case StatusInvalid:
+			status = s
+		}
+	}
+	return status
+}
+
+// verify checks sig: signed, if it is made with the key of v for digest;
+// invalid, if it is made with the key of v for something else
+func (v *Verifier) verify(sig Signature, digest string) Status {
+
+	raw, err := base64.StdEncoding.DecodeString(sig.Signature)
+	if err != nil {
+		return StatusOtherKey
+	}
+
+	var hash []byte
+	var names func() bool
+	switch sig.Format {
+	case FormatDSSE:
+		sum := sha256.Sum256(pae(sig.PayloadType, sig.Payload))
+		hash = sum[:]
+		names = func() bool { return statementNames(sig.Payload, digest) }
+	case FormatDigest:
+		_, hexDigest, _ := strings.Cut(string(sig.Payload), ":")
+		if hash, err = hex.DecodeString(hexDigest); err != nil {
+			return StatusOtherKey
+		}
+		names = func() bool { return string(sig.Payload) == digest }
+	default:
+		sum := sha256.Sum256(sig.Payload)
+		hash = sum[:]
+		names = func() bool {
+			p := payload{}
+			return json.Unmarshal(sig.Payload, &p) == nil && p.Critical.Image.Digest == digest
+		}
+	}
+
+	if !ecdsa.VerifyASN1(v.key, hash, raw) {
+		return StatusOtherKey
+	}
+	if !names() {
+		return StatusInvalid
+	}
+	return StatusSigned
+}
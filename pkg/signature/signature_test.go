@@ -0,0 +1,115 @@
+package signature
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+func TestVerify(t *testing.T) {
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	digest := "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+	sign := func(k *ecdsa.PrivateKey, digest string) Signature {
+		p := []byte(`{"critical":{"identity":{"docker-reference":"example.com/app"},"image":{"docker-manifest-digest":"` + digest + `"},"type":"cosign container image signature"}}`)
+		hash := sha256.Sum256(p)
+		raw, err := ecdsa.SignASN1(rand.Reader, k, hash[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		return Signature{Payload: p, Signature: base64.StdEncoding.EncodeToString(raw)}
+	}
+	signDSSE := func(k *ecdsa.PrivateKey, digest string) Signature {
+		p := []byte(`{"_type":"https://in-toto.io/Statement/v1","subject":[{"name":"example.com/app","digest":{"sha256":"` + strings.TrimPrefix(digest, "sha256:") + `"}}],"predicateType":"https://sigstore.dev/cosign/sign/v1","predicate":{}}`)
+		hash := sha256.Sum256(pae("application/vnd.in-toto+json", p))
+		raw, err := ecdsa.SignASN1(rand.Reader, k, hash[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		return Signature{Format: FormatDSSE, Payload: p, PayloadType: "application/vnd.in-toto+json", Signature: base64.StdEncoding.EncodeToString(raw)}
+	}
+	signDigest := func(k *ecdsa.PrivateKey, digest string) Signature {
+		hash, _ := hex.DecodeString(strings.TrimPrefix(digest, "sha256:"))
+		raw, err := ecdsa.SignASN1(rand.Reader, k, hash)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return Signature{Format: FormatDigest, Payload: []byte(digest), Signature: base64.StdEncoding.EncodeToString(raw)}
+	}
+	otherDigest := "sha256:fedcba9876543210fedcba9876543210fedcba9876543210fedcba9876543210"
+
+	fixtures := [...]struct {
+		Name     string
+		Sigs     []Signature
+		Expected Status
+	}{
+		{"none", nil, StatusUnsigned},
+		{"valid", []Signature{sign(key, digest)}, StatusSigned},
+		{"other key", []Signature{sign(other, digest)}, StatusOtherKey},
+		{"other digest", []Signature{sign(key, "sha256:00")}, StatusInvalid},
+		{"one valid", []Signature{sign(other, digest), sign(key, digest)}, StatusSigned},
+		{"other key and digest", []Signature{sign(other, digest), sign(key, "sha256:00")}, StatusInvalid},
+		{"garbage", []Signature{{Payload: []byte("{}"), Signature: "!!"}}, StatusOtherKey},
+		{"dsse", []Signature{signDSSE(key, digest)}, StatusSigned},
+		{"dsse other key", []Signature{signDSSE(other, digest)}, StatusOtherKey},
+		{"dsse other digest", []Signature{signDSSE(key, otherDigest)}, StatusInvalid},
+		{"digest", []Signature{signDigest(key, digest)}, StatusSigned},
+		{"digest other key", []Signature{signDigest(other, digest)}, StatusOtherKey},
+		{"digest other digest", []Signature{signDigest(key, otherDigest)}, StatusInvalid},
+	}
+
+	v := NewVerifier(&key.PublicKey)
+	for _, f := range fixtures {
+		if actual := v.Verify(f.Sigs, digest); actual != f.Expected {
+			t.Fatalf("%s: expected: %q, actual: %q", f.Name, f.Expected, actual)
+		}
+	}
+}
+
+func TestTag(t *testing.T) {
+
+	if actual := Tag("sha256:abc"); actual != "sha256-abc.sig" {
+		t.Fatalf("expected: %q, actual: %q", "sha256-abc.sig", actual)
+	}
+}
+
+func TestParseBundle(t *testing.T) {
+
+	fixtures := [...]struct {
+		Bundle          string
+		ExpectedFormat  string
+		ExpectedPayload string
+		ExpectedErr     bool
+	}{
+		{`{"dsseEnvelope":{"payload":"e30=","payloadType":"application/vnd.in-toto+json","signatures":[{"sig":"c2ln"}]}}`, FormatDSSE, "{}", false},
+		{`{"messageSignature":{"messageDigest":{"algorithm":"SHA2_256","digest":"q80="},"signature":"c2ln"}}`, FormatDigest, "sha256:abcd", false},
+		{`{"messageSignature":{"messageDigest":{"algorithm":"SHA2_384","digest":"q80="},"signature":"c2ln"}}`, "", "", true},
+		{`{"verificationMaterial":{}}`, "", "", true},
+	}
+
+	for _, f := range fixtures {
+		sigs, err := ParseBundle([]byte(f.Bundle))
+		if (err != nil) != f.ExpectedErr {
+			t.Fatalf("%s: expected error: %t, actual: %v", f.Bundle, f.ExpectedErr, err)
+		}
+		if f.ExpectedErr {
+			continue
+		}
+		if len(sigs) != 1 || sigs[0].Format != f.ExpectedFormat || string(sigs[0].Payload) != f.ExpectedPayload || sigs[0].Signature != "c2ln" {
+			t.Fatalf("%s: expected: %q %q, actual: %+v", f.Bundle, f.ExpectedFormat, f.ExpectedPayload, sigs)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package state
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+)
+
+// Digests holds the manifest digests of checked images between runs, keyed
+// by the image name including its tag. A digest differing from the one of
+// the previous run means the tag was re-pushed.
+type Digests map[string]string
+
+// Load reads the digests from the file at path. A missing file yields no
+// digests: the first run has nothing to compare against.
+func Load(path string) (Digests, error) {
+
+	data, err := os.ReadFile(path) // #nosec G304
+	if errors.Is(err, fs.ErrNotExist) {
+		return Digests{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	digests := Digests{}
+	if err := json.Unmarshal(data, &digests); err != nil {
+		return nil, err
+	}
+	return digests, nil
+}
+
+// Save writes the digests to the file at path
+func (d Digests) Save(path string) error {
+
+	data, err := json.MarshalIndent(d, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o600)
+}
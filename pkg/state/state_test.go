@@ -0,0 +1,32 @@
+package state
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadSave(t *testing.T) {
+
+	path := filepath.Join(t.TempDir(), "state.json")
+
+	digests, err := Load(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(digests) != 0 {
+		t.Fatalf("expected: %d, actual: %d", 0, len(digests))
+	}
+
+	digests["docker.io/library/alpine:3.19"] = "sha256:b"
+	if err := digests.Save(path); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if actual := loaded["docker.io/library/alpine:3.19"]; actual != "sha256:b" {
+		t.Fatalf("expected: %q, actual: %q", "sha256:b", actual)
+	}
+}
@@ -13,11 +13,67 @@ type AllStats struct {
 	NonTagged   int
 	InvalidSpec int
 
+	FilteredByPattern int
+
+	// Missing counts the images whose tag is gone from the registry, Mutated
+	// the ones whose tag points to another image than pinned or before
+	Missing int
+	Mutated int
+
+	// EOL counts the images whose release cycle reached its end of life,
+	// NearEOL the ones close to it
+	EOL     int
+	NearEOL int
+
+	// RebuildNeeded counts the images whose base image was updated since
+	// they were built
+	RebuildNeeded int
+
+	// Vulnerable counts the images with vulnerabilities as reported by a
+	// scanner, Vulns the vulnerabilities per severity
+	Vulnerable int
+	Vulns      map[string]int
+
+	// Updates counts the images per kind of their newest update ("major",
+	// "minor", "patch", …)
+	Updates map[string]int
+
+	// Behind sums up how far the checked images are behind their newest
+	// tags
+	Behind BehindStats
+
 	Fetch FetchStats
 }
 
+// BehindStats sums up the newer releases, the newer major and minor lines
+// and the libyears (age difference to the newest tag in years) of images
+type BehindStats struct {
+	Releases int
+	Majors   int
+	Minors   int
+	Libyears float64
+}
+
 // FetchStats is used to collect all kind of fetch-related stats
 type FetchStats struct {
 	Duration time.Duration
 	Fetched  int
 }
+
+// CountUpdate counts an image with the newest update of the given kind
+func (s *AllStats) CountUpdate(kind string) {
+	if s.Updates == nil {
+		s.Updates = map[string]int{}
+	}
+	s.Updates[kind]++
+}
+
+// CountVulns adds the vulnerabilities per severity of an image
+func (s *AllStats) CountVulns(counts map[string]int) {
+	if s.Vulns == nil {
+		s.Vulns = map[string]int{}
+	}
+	for severity, n := range counts {
+		s.Vulns[severity] += n
+	}
+}
@@ -0,0 +1,162 @@
+package tag
+
+import (
+	"slices"
+	"strings"
+)
+
+// DigestFunc returns the digest of the image t points to; "" if unknown
+type DigestFunc func(t *Tag) string
+
+// MayAlias reports whether a and b might point to the same image: the
+// variants are the same and one version contains the other, like "3.19"
+// and "3.19.1" or "3" and "3.19.1". Only such tags are looked up by
+// Collapse.
+func MayAlias(a, b *Tag) bool {
+
+	if a.Scheme != b.Scheme || a.Variant.String() != b.Variant.String() {
+		return false
+	}
+	return a.Version.Contains(b.Version) || b.Version.Contains(a.Version)
+}
+
+// Collapse collapses the tags of the list which point to the same image,
+// according to digest, into the most specific tag of them ("3.19.1" over
+// "3.19" and "3"); the others are kept as its Aliases. Only the first limit
+// tags of the list are collapsed (all, if limit is 0), the digests are
+// fetched only for tags which MayAlias these.
+func (tags List) Collapse(limit int, digest DigestFunc) List {
+
+	collapsed := make(List, 0, len(tags))
+	aliased := make([]bool, len(tags))
+	n := 0
+
+	for i, t := range tags {
+
+		if aliased[i] {
+			continue
+		}
+		if limit > 0 && n >= limit {
+			collapsed = append(collapsed, t)
+			continue
+		}
+		n++
+
+		group := List{t}
+		for j := i + 1; j < len(tags); j++ {
+			if aliased[j] || !MayAlias(t, tags[j]) {
+				continue
+			}
+			if d := digest(t); d != "" && d == digest(tags[j]) {
+				group = append(group, tags[j])
+				aliased[j] = true
+			}
+		}
+
+		collapsed = append(collapsed, group.mostSpecific())
+	}
+
+	return collapsed
+}
+
+// mostSpecific returns the most specific tag of the group, the others
+// become its aliases
+func (tags List) mostSpecific() *Tag {
+
+	best := 0
+	for i := range tags {
+		if specificity(tags[i]) > specificity(tags[best]) {
+			best = i
+		}
+	}
+
+	head := tags[best]
+	for i := range tags {
+		if i != best {
+			head.Aliases = append(head.Aliases, tags[i].String())
+		}
+	}
+	return head
+}
+
+// specificity returns the number of components of the version of t as
+// spelled, plus one for a revision: "3" => 1, "3.19.1" => 3, "3.18.4-r2" => 4
+func specificity(t *Tag) int {
+
+	n := components(t)
+	if t.Revision > 0 {
+		n++
+	}
+	return n
+}
+
+// components returns the number of components of the version of t as
+// spelled; 0 for schemes without numbered components
+func components(t *Tag) int {
+
+	switch v := t.Version.(type) {
+	case SemVer:
+		return strings.Count(originalCore(v.Version), ".") + 1
+	case Numeric:
+		return len(v.Segments)
+	case CalVer:
+		return len(v.Segments)
+	}
+	return 0
+}
+
+// IsFloating reports whether t is a partial semantic version ("3",
+// "3.19") which moves on with new releases
+func (t *Tag) IsFloating() bool {
+	return t.Scheme == SemVerScheme && components(t) < 3
+}
+
+// FloatingNames lists the names of tags which are no version but move on
+// with new releases
+var FloatingNames = []string{"latest", "stable", "lts", "current", "mainline", "edge"}
+
+// IsFloatingName reports whether name is one of FloatingNames ("latest",
+// "stable", …)
+func IsFloatingName(name string) bool {
+	return slices.Contains(FloatingNames, strings.ToLower(name))
+}
+
+// Resolve returns the most specific tag of the list (sorted, newest first)
+// which points to the image with the given digest. If floating is given
+// ("3", "3.19-alpine"), only tags it contains are looked up, otherwise
+// ("latest", "stable") the tags without a variant. At most limit tags are
+// looked up (all, if limit is 0). nil is returned if no tag matches.
+func (tags List) Resolve(floating *Tag, digest string, digestOf DigestFunc, limit int) *Tag {
+
+	mayMatch := func(t *Tag) bool {
+		if floating == nil {
+			return t.Variant.String() == ""
+		}
+		return MayAlias(floating, t) && floating.Version.Contains(t.Version)
+	}
+
+	looked := 0
+	for i, t := range tags {
+		if !mayMatch(t) {
+			continue
+		}
+		if limit > 0 && looked >= limit {
+			break
+		}
+		looked++
+
+		if digestOf(t) != digest {
+			continue
+		}
+
+		group := List{t}
+		for _, o := range tags[i+1:] {
+			if MayAlias(t, o) && mayMatch(o) && digestOf(o) == digest {
+				group = append(group, o)
+			}
+		}
+		return group.mostSpecific()
+	}
+
+	return nil
+}
@@ -0,0 +1,147 @@
+package tag
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCollapse(t *testing.T) {
+
+	in := []string{"3", "3.18", "3.18.6", "3.19", "3.19.0", "3.19.1", "3.19.1-alpine"}
+	digests := map[string]string{
+		"3":             "sha256:b",
+		"3.19":          "sha256:b",
+		"3.19.1":        "sha256:b",
+		"3.19.1-alpine": "sha256:b",
+		"3.19.0":        "sha256:a",
+		"3.18":          "sha256:c",
+		"3.18.6":        "sha256:c",
+	}
+
+	fixtures := [...]struct {
+		Limit          int
+		Expected       []string
+		ExpectedLookup bool // "3.18" gets looked up
+	}{
+		{1, []string{"3.19.1 (3.19, 3)", "3.19.0", "3.18.6", "3.18"}, false},
+		{0, []string{"3.19.1 (3.19, 3)", "3.19.0", "3.18.6 (3.18)"}, true},
+	}
+
+	for _, f := range fixtures {
+
+		lookups := map[string]int{}
+		digest := func(t *Tag) string {
+			lookups[t.String()]++
+			return digests[t.String()]
+		}
+
+		tags := NewFromStrings(in, SemVerScheme, nil, VariantFilter(Variant{}))
+		tags.Sort()
+		tags.Reverse()
+
+		actual := []string{}
+		for _, t := range tags.Collapse(f.Limit, digest) {
+			s := t.String()
+			if len(t.Aliases) > 0 {
+				s += " (" + strings.Join(t.Aliases, ", ") + ")"
+			}
+			actual = append(actual, s)
+		}
+
+		if strings.Join(actual, "|") != strings.Join(f.Expected, "|") {
+			t.Fatalf("limit %d: expected: %q, actual: %q", f.Limit, f.Expected, actual)
+		}
+		if looked := lookups["3.18"] > 0; looked != f.ExpectedLookup {
+			t.Fatalf("limit %d: expected: %t, actual: %t", f.Limit, f.ExpectedLookup, looked)
+		}
+	}
+}
+
+func TestResolve(t *testing.T) {
+
+	in := []string{"3", "3.18", "3.18.6", "3.19", "3.19.0", "3.19.1", "3.19.1-alpine", "3.20.0-rc.1"}
+	digests := map[string]string{
+		"latest":        "sha256:b",
+		"3":             "sha256:b",
+		"3.19":          "sha256:b",
+		"3.19.1":        "sha256:b",
+		"3.19.1-alpine": "sha256:d",
+		"3.19.0":        "sha256:a",
+		"3.18":          "sha256:c",
+		"3.18.6":        "sha256:c",
+		"3.20.0-rc.1":   "sha256:e",
+	}
+	digest := func(t *Tag) string { return digests[t.String()] }
+
+	tags := NewFromStrings(in, SemVerScheme, nil, ApplyFilterList(nil))
+	tags.Sort()
+	tags.Reverse()
+
+	fixtures := [...]struct {
+		Floating string
+		Expected string
+	}{
+		{"latest", "3.19.1"},
+		{"3", "3.19.1"},
+		{"3.18", "3.18.6"},
+		{"3.19.0", "3.19.0"},
+	}
+
+	for _, f := range fixtures {
+
+		var floating *Tag
+		if f.Floating != "latest" {
+			floating, _ = Parse(f.Floating)
+		}
+
+		actual := ""
+		if resolved := tags.Resolve(floating, digests[f.Floating], digest, 0); resolved != nil {
+			actual = resolved.String()
+		}
+		if actual != f.Expected {
+			t.Fatalf("%q: expected: %q, actual: %q", f.Floating, f.Expected, actual)
+		}
+	}
+}
+
+func TestIsFloatingName(t *testing.T) {
+
+	fixtures := [...]struct {
+		Tag      string
+		Expected bool
+	}{
+		{"latest", true},
+		{"Stable", true},
+		{"edge", true},
+		{"sha-1a2b3c4", false},
+		{"buildcache", false},
+	}
+
+	for _, f := range fixtures {
+		if actual := IsFloatingName(f.Tag); actual != f.Expected {
+			t.Fatalf("%q: expected: %t, actual: %t", f.Tag, f.Expected, actual)
+		}
+	}
+}
+
+func TestIsFloating(t *testing.T) {
+
+	fixtures := [...]struct {
+		Tag      string
+		Expected bool
+	}{
+		{"3", true},
+		{"3.19", true},
+		{"3.19-alpine", true},
+		{"3.19.1", false},
+		{"v3.19.1-alpine", false},
+		{"jammy-20240427", false},
+	}
+
+	for _, f := range fixtures {
+		tag, _ := Parse(f.Tag)
+		if actual := tag.IsFloating(); actual != f.Expected {
+			t.Fatalf("%q: expected: %t, actual: %t", f.Tag, f.Expected, actual)
+		}
+	}
+}
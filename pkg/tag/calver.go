@@ -0,0 +1,162 @@
+package tag
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// CalVer is a calendar based version. The tag might carry a prefix and a
+// suffix around the date, these become the Variant of the Tag:
+//
+//	"20240427"                     => 2024 04 27
+//	"2024.05.01", "2024-05-01"     => 2024 05 01
+//	"2024.05"                      => 2024 05
+//	"jammy-20240427"               => 2024 04 27, Variant "jammy"
+//	"RELEASE.2024-05-01T01-11-10Z" => 2024 05 01 01 11 10, Variant "RELEASE"
+//
+// CalVer versions are compared chronologically.
+type CalVer struct {
+	// Segments contains year, month, day, hour, minute and second - as far
+	// as they were given
+	Segments []int
+}
+
+// reCalVer matches a date (plus optional time) which is neither preceded nor
+// followed by other digits. The prefix must not contain any digits, otherwise
+// "1.2.3-20240105" would be taken as a date with "1.2.3" as prefix.
+var reCalVer = regexp.MustCompile(`^([^0-9]*)` +
+	`((?:19|20)\d{2})(?:(\d{2})(\d{2})|[.-](\d{1,2})(?:[.-](\d{1,2}))?)` +
+	`(?:T(\d{2})[-:]?(\d{2})[-:]?(\d{2})Z?)?` +
+	`([^0-9].*)?$`)
+
+const sepsCalVer = ".-_"
+
+var errNoCalVer = errors.New("not a calendar version")
+
+// ParseCalVer parses s into a CalVer plus the prefix and suffix around the
+// date
+func ParseCalVer(s string) (v CalVer, prefix, suffix string, err error) {
+
+	m := reCalVer.FindStringSubmatch(s)
+	if m == nil {
+		return v, prefix, suffix, errNoCalVer
+	}
+
+	prefix, suffix = m[1], m[10]
+
+	fields := []string{m[2], m[3], m[4], m[7], m[8], m[9]}
+	if m[3] == "" {
+		fields[1], fields[2] = m[5], m[6]
+	}
+
+	limits := []int{9999, 12, 31, 23, 59, 59}
+	for i, f := range fields {
+		if f == "" {
+			break
+		}
+		n, _ := strconv.Atoi(f)
+		if (i == 1 || i == 2) && n < 1 || n > limits[i] {
+			return CalVer{}, "", "", fmt.Errorf("%w: %q out of range", errNoCalVer, f)
+		}
+		v.Segments = append(v.Segments, n)
+	}
+
+	return v, prefix, suffix, nil
+}
+
+// Compare compares v and o chronologically
+func (v CalVer) Compare(o Version) int {
+
+	ov, ok := o.(CalVer)
+	if !ok {
+		return 0
+	}
+
+	for i := 0; i < max(len(v.Segments), len(ov.Segments)); i++ {
+		a, b := segment(v.Segments, i), segment(ov.Segments, i)
+		switch {
+		case a < b:
+			return -1
+		case a > b:
+			return 1
+		}
+	}
+	return 0
+}
+
+// Contains reports if o lies within the period given by v: "2024.05"
+// contains "2024.05.17"
+func (v CalVer) Contains(o Version) bool {
+
+	ov, ok := o.(CalVer)
+	if !ok {
+		return false
+	}
+
+	return v.sharesPrefix(ov, len(v.Segments))
+}
+
+// sharesPrefix reports if the first n segments of v and o are equal
+func (v CalVer) sharesPrefix(o CalVer, n int) bool {
+
+	for i := 0; i < n; i++ {
+		if segment(v.Segments, i) != segment(o.Segments, i) {
+			return false
+		}
+	}
+	return true
+}
+
+// String satisfies the Stringer interface
+func (v CalVer) String() string {
+
+	parts := make([]string, len(v.Segments))
+	for i, n := range v.Segments {
+		parts[i] = fmt.Sprintf("%02d", n)
+	}
+	return strings.Join(parts, ".")
+}
+
+func segment(segments []int, i int) int {
+	if i < len(segments) {
+		return segments[i]
+	}
+	return 0
+}
+
+type calverScheme struct{}
+
+func (calverScheme) Name() string { return "calver" }
+
+func (calverScheme) Parse(s string) (*Tag, error) {
+
+	v, prefix, suffix, err := ParseCalVer(s)
+	if err != nil {
+		return nil, err
+	}
+
+	parts := []string{}
+	for _, p := range []string{prefix, suffix} {
+		if p = strings.Trim(p, sepsCalVer); p != "" {
+			parts = append(parts, p)
+		}
+	}
+
+	return newTag(CalVerScheme, v, strings.Join(parts, sepVariantParts), s), nil
+}
+
+// Keep only keeps calendar versions sharing the year (KeepMajor), the year
+// and month (KeepMinor) or the day (KeepPatch) with base - as far as base
+// has them
+func (calverScheme) Keep(base *Tag, keepLevel int) FilterFunc {
+
+	bv, _ := base.Version.(CalVer)
+	n := min(keptComponents(keepLevel), len(bv.Segments))
+	return func(t *Tag) bool {
+		v, ok := t.Version.(CalVer)
+		return ok && bv.sharesPrefix(v, n)
+	}
+}
@@ -0,0 +1,108 @@
+package tag
+
+import (
+	"testing"
+)
+
+func TestParseCalVer(t *testing.T) {
+
+	fixtures := [...]struct {
+		Tag             string
+		ExpectedVersion string
+		ExpectedPrefix  string
+		ExpectedSuffix  string
+		ExpectedErr     bool
+	}{
+		{"20240427", "2024.04.27", "", "", false},
+		{"2024.05.01", "2024.05.01", "", "", false},
+		{"2024-05-01", "2024.05.01", "", "", false},
+		{"2024.05", "2024.05", "", "", false},
+		{"jammy-20240427", "2024.04.27", "jammy-", "", false},
+		{"RELEASE.2024-05-01T01-11-10Z", "2024.05.01.01.11.10", "RELEASE.", "", false},
+		{"20240427-slim", "2024.04.27", "", "-slim", false},
+		{"20241327", "", "", "", true},
+		{"202404271", "", "", "", true},
+		{"1.2.3-20240105", "", "", "", true},
+		{"3.19.1", "", "", "", true},
+		{"latest", "", "", "", true},
+	}
+
+	for _, f := range fixtures {
+		v, prefix, suffix, err := ParseCalVer(f.Tag)
+
+		t.Logf("%q => %q %q %q %v", f.Tag, v, prefix, suffix, err)
+
+		if f.ExpectedErr != (err != nil) {
+			t.Fatalf("expected error: %v, actual: %v", f.ExpectedErr, err)
+		}
+		if err != nil {
+			continue
+		}
+		if f.ExpectedVersion != v.String() {
+			t.Fatalf("expected: %q, actual: %q", f.ExpectedVersion, v.String())
+		}
+		if f.ExpectedPrefix != prefix {
+			t.Fatalf("expected: %q, actual: %q", f.ExpectedPrefix, prefix)
+		}
+		if f.ExpectedSuffix != suffix {
+			t.Fatalf("expected: %q, actual: %q", f.ExpectedSuffix, suffix)
+		}
+	}
+}
+
+func TestCompareCalVer(t *testing.T) {
+
+	fixtures := [...]struct {
+		Base            string
+		Other           string
+		ExpectedVerdict Verdict
+	}{
+		{"20240427", "20240501", VerdictAhead},
+		{"20240427", "20240427", VerdictEqual},
+		{"20240427", "20231231", VerdictOutdated},
+		{"2024.05", "2024.05.17", VerdictEqual},
+		{"2024.05", "2024.06.01", VerdictAhead},
+		{"RELEASE.2024-05-01T01-11-10Z", "RELEASE.2024-05-01T02-00-00Z", VerdictAhead},
+	}
+
+	for _, f := range fixtures {
+		base, _ := Parse(f.Base)
+		other, _ := Parse(f.Other)
+
+		verdict, _ := Compare(base, other)
+
+		t.Logf("%q vs %q => %q", f.Base, f.Other, verdict)
+
+		if f.ExpectedVerdict != verdict {
+			t.Fatalf("expected: %q, actual: %q", f.ExpectedVerdict, verdict)
+		}
+	}
+}
+
+func TestSchemeFilter(t *testing.T) {
+
+	fixtures := [...]struct {
+		Base     string
+		Tags     []string
+		Expected []string
+	}{
+		{"3.19", []string{"3.19.1", "20210530", "bookworm", "2024.05"}, []string{"3.19.1"}},
+		{"20240427", []string{"3.19.1", "20210530", "jammy-20240501"}, []string{"20210530"}},
+		{"jammy-20240427", []string{"jammy-20240501", "noble-20240501", "20240501"}, []string{"jammy-20240501"}},
+		{"bullseye", []string{"bookworm", "12.5", "20240501"}, []string{"bookworm"}},
+	}
+
+	for _, f := range fixtures {
+		base, _ := Parse(f.Base)
+		tags := NewFromStrings(f.Tags, base.Scheme, nil, SchemeFilter(base))
+
+		if len(f.Expected) != len(tags) {
+			t.Fatalf("%q: expected: %q, actual: %q", f.Base, f.Expected, tags)
+		}
+		for i := range tags {
+			if f.Expected[i] != tags[i].String() {
+				t.Fatalf("%q: expected: %q, actual: %q", f.Base, f.Expected[i], tags[i])
+			}
+		}
+	}
+}
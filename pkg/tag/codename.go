@@ -0,0 +1,144 @@
+package tag
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Codenames maps the name of a distribution to the codenames of its
+// releases, ordered from oldest to newest.
+type Codenames map[string][]string
+
+// DefaultCodenames contains the codenames of the distributions most
+// commonly found in tags of official images ("python:3.11-bookworm",
+// "eclipse-temurin:17-jdk-jammy")
+var DefaultCodenames = Codenames{
+	"debian": {
+		"hamm", "slink", "potato", "woody", "sarge", "etch", "lenny",
+		"squeeze", "wheezy", "jessie", "stretch", "buster", "bullseye",
+		"bookworm", "trixie", "forky", "duke",
+	},
+	"ubuntu": {
+		"warty", "hoary", "breezy", "dapper", "edgy", "feisty", "gutsy",
+		"hardy", "intrepid", "jaunty", "karmic", "lucid", "maverick",
+		"natty", "oneiric", "precise", "quantal", "raring", "saucy",
+		"trusty", "utopic", "vivid", "wily", "xenial", "yakkety", "zesty",
+		"artful", "bionic", "cosmic", "disco", "eoan", "focal", "groovy",
+		"hirsute", "impish", "jammy", "kinetic", "lunar", "mantic", "noble",
+		"oracular", "plucky", "questing",
+	},
+}
+
+type codenameEntry struct {
+	distro string
+	index  int
+}
+
+var codenames = indexCodenames(DefaultCodenames)
+
+// SetCodenames overrides the codename ordering of the distributions given
+// in c. Distributions not mentioned in c keep their default ordering.
+func SetCodenames(c Codenames) {
+
+	merged := Codenames{}
+	for distro, names := range DefaultCodenames {
+		merged[distro] = names
+	}
+	for distro, names := range c {
+		merged[distro] = names
+	}
+
+	codenames = indexCodenames(merged)
+}
+
+// LookupCodename returns the distribution name belongs to and the position of
+// name within the releases of that distribution
+func LookupCodename(name string) (distro string, index int, ok bool) {
+
+	e, ok := codenames[name]
+	return e.distro, e.index, ok
+}
+
+func indexCodenames(c Codenames) map[string]codenameEntry {
+
+	idx := map[string]codenameEntry{}
+	for distro, names := range c {
+		for i, name := range names {
+			idx[name] = codenameEntry{distro: distro, index: i}
+		}
+	}
+	return idx
+}
+
+// compareCodenames compares the codenames a and b. Codenames of different
+// distributions (or unknown ones) are not comparable and yield 0.
+func compareCodenames(a, b string) int {
+
+	da, ia, oka := LookupCodename(a)
+	db, ib, okb := LookupCodename(b)
+	if !oka || !okb || da != db {
+		return 0
+	}
+
+	switch {
+	case ia < ib:
+		return -1
+	case ia > ib:
+		return 1
+	}
+	return 0
+}
+
+// Codename is the version of a tag which consists of the codename of a
+// distribution release: "bookworm", "jammy", "bookworm-slim"
+type Codename struct {
+	Name   string
+	Distro string
+}
+
+// Compare compares the release order of v and o
+func (v Codename) Compare(o Version) int {
+
+	ov, ok := o.(Codename)
+	if !ok {
+		return 0
+	}
+	return compareCodenames(v.Name, ov.Name)
+}
+
+// Contains reports if o names the same release as v
+func (v Codename) Contains(o Version) bool {
+
+	ov, ok := o.(Codename)
+	return ok && v == ov
+}
+
+// String satisfies the Stringer interface
+func (v Codename) String() string {
+	return v.Name
+}
+
+type codenameScheme struct{}
+
+func (codenameScheme) Name() string { return "codename" }
+
+func (codenameScheme) Parse(s string) (*Tag, error) {
+
+	name, suffix, _ := strings.Cut(s, sepVariantParts)
+
+	distro, _, ok := LookupCodename(name)
+	if !ok {
+		return nil, fmt.Errorf("unknown codename %q", name)
+	}
+
+	return newTag(CodenameScheme, Codename{Name: name, Distro: distro}, suffix, s), nil
+}
+
+// Keep only keeps tags of the same release as base, a codename has no
+// finer levels
+func (codenameScheme) Keep(base *Tag, keepLevel int) FilterFunc {
+
+	return func(t *Tag) bool {
+		return base.Version.Contains(t.Version)
+	}
+}
@@ -0,0 +1,32 @@
+package tag
+
+import (
+	"testing"
+)
+
+func TestSetCodenames(t *testing.T) {
+
+	defer SetCodenames(nil)
+
+	SetCodenames(Codenames{"example": {"bravo", "alpha"}})
+
+	fixtures := [...]struct {
+		A        string
+		B        string
+		Expected int
+	}{
+		{"alpha", "bravo", 1},
+		{"bravo", "alpha", -1},
+		{"alpha", "alpha", 0},
+		{"bullseye", "bookworm", -1},
+		{"alpha", "bookworm", 0},
+		{"unknown", "alpha", 0},
+	}
+
+	for _, f := range fixtures {
+		c := compareCodenames(f.A, f.B)
+		if f.Expected != c {
+			t.Fatalf("%q vs %q: expected: %d, actual: %d", f.A, f.B, f.Expected, c)
+		}
+	}
+}
@@ -0,0 +1,181 @@
+package tag
+
+// Verdict describes how a tag relates to the base tag. VerdictMissing and
+// VerdictMutated describe the requested tag itself: it is gone from the
+// registry or it points to another image than before.
+type Verdict int
+
+const (
+	VerdictNone Verdict = iota
+	VerdictAhead
+	VerdictEqual
+	VerdictOutdated
+	VerdictMissing
+	VerdictMutated
+)
+
+var verdictNames = []string{"", "ahead", "equal", "outdated", "missing", "mutated"}
+
+// String satisfies the Stringer interface
+func (v Verdict) String() string {
+	return verdictNames[v]
+}
+
+// Invert returns the verdict from the point of view of the base tag: if
+// a tag is ahead of the base, the base is outdated
+func (v Verdict) Invert() Verdict {
+	switch v {
+	case VerdictAhead:
+		return VerdictOutdated
+	case VerdictOutdated:
+		return VerdictAhead
+	}
+	return v
+}
+
+// UpdateKind describes which component of a tag was updated
+type UpdateKind string
+
+const (
+	UpdateNone       UpdateKind = ""
+	UpdateMajor      UpdateKind = "major"
+	UpdateMinor      UpdateKind = "minor"
+	UpdatePatch      UpdateKind = "patch"
+	UpdatePrerelease UpdateKind = "prerelease"
+	UpdateRebuild    UpdateKind = "rebuild"
+	UpdateVariant    UpdateKind = "variant"
+	UpdateDistro     UpdateKind = "distro"
+)
+
+// UpdateKinds lists all kinds of updates, from the most to the least
+// significant one
+var UpdateKinds = []UpdateKind{
+	UpdateMajor, UpdateMinor, UpdatePatch, UpdatePrerelease,
+	UpdateRebuild, UpdateVariant, UpdateDistro,
+}
+
+// Compare compares the tag other against base and returns the verdict for
+// other plus the kind of update other is for base: a newer application
+// version ("1.25.3-alpine3.18" => "1.26.0-alpine3.18" is a minor update,
+// "1.25.3" => "1.26.0-rc.1" a pre-release) or a newer pre-release of the
+// same version ("1.26.0-rc.1" => "1.26.0-rc.2") or its release
+// ("1.26.0-rc.2" => "1.26.0" is a minor update) or a newer variant of the
+// same application version ("1.25.3-alpine3.18" => "1.25.3-alpine3.19") or
+// a newer release of the base distribution ("3.11-bullseye" =>
+// "3.11-bookworm", "bullseye" => "bookworm") or a rebuild of the same
+// version ("3.18.4-r1" => "3.18.4-r2").
+func Compare(base, other *Tag) (Verdict, UpdateKind) {
+
+	// in case, "base" was given as "8.4" … the verdict
+	// should be equal upon 8.4.1 or 8.4.99.
+	if base.Version.Contains(other.Version) {
+		if other.Version.Compare(base.Version) == 0 {
+			switch {
+			case other.Revision > base.Revision:
+				return VerdictAhead, UpdateRebuild
+			case other.Revision < base.Revision:
+				return VerdictOutdated, UpdateNone
+			}
+			if verdict, ok := comparePrereleases(base, other); ok {
+				switch {
+				case verdict != VerdictAhead:
+					return verdict, UpdateNone
+				case Channel(other) == "":
+					return verdict, releaseUpdateKind(other)
+				}
+				return verdict, UpdatePrerelease
+			}
+		}
+		return compareVariants(base, other)
+	}
+
+	switch c := other.Version.Compare(base.Version); {
+	case c > 0:
+		return VerdictAhead, versionUpdateKind(base, other)
+	case c < 0:
+		return VerdictOutdated, UpdateNone
+	}
+	return compareVariants(base, other)
+}
+
+// versionUpdateKind classifies the update from base to the newer version of
+// other. The levels are derived from the Keep filters of the scheme of base.
+func versionUpdateKind(base, other *Tag) UpdateKind {
+
+	switch {
+	case base.Scheme == CodenameScheme:
+		return UpdateDistro
+	case Channel(other) != "":
+		return UpdatePrerelease
+	case !base.Scheme.Keep(base, KeepMajor)(other):
+		return UpdateMajor
+	case !base.Scheme.Keep(base, KeepMinor)(other):
+		return UpdateMinor
+	}
+	return UpdatePatch
+}
+
+// releaseUpdateKind classifies the release of the version of other, which
+// follows its pre-releases: "3.19.0-rc.2" => "3.19.0" releases a minor
+// version, "4.0.0-rc.1" => "4.0.0" a major one. Versions which can not be
+// expressed as semver are taken as patches.
+func releaseUpdateKind(other *Tag) UpdateKind {
+
+	v := asSemVer(other.Version)
+	switch {
+	case v == nil, v.Patch() > 0:
+		return UpdatePatch
+	case v.Minor() > 0:
+		return UpdateMinor
+	}
+	return UpdateMajor
+}
+
+// comparePrereleases compares base and other of the same version, if at
+// least one of them is a pre-release: the release is ahead of its
+// pre-releases, pre-releases are compared by their semantic versions. ok is
+// false if the tags are not comparable that way.
+func comparePrereleases(base, other *Tag) (verdict Verdict, ok bool) {
+
+	bc, oc := Channel(base), Channel(other)
+
+	switch {
+	case bc == "" && oc == "":
+		return VerdictNone, false
+	case oc == "":
+		return VerdictAhead, true
+	case bc == "":
+		return VerdictOutdated, true
+	}
+
+	a, b := base.SemVer(), other.SemVer()
+	if a == nil || b == nil {
+		return VerdictNone, false
+	}
+
+	switch c := b.Compare(a); {
+	case c > 0:
+		return VerdictAhead, true
+	case c < 0:
+		return VerdictOutdated, true
+	}
+	return VerdictEqual, true
+}
+
+func compareVariants(base, other *Tag) (Verdict, UpdateKind) {
+	if other.Variant.Name == base.Variant.Name {
+		switch c := compareCodenames(other.Variant.Codename, base.Variant.Codename); {
+		case c > 0:
+			return VerdictAhead, UpdateDistro
+		case c < 0:
+			return VerdictOutdated, UpdateNone
+		}
+	}
+	switch c := other.Variant.Compare(base.Variant); {
+	case c > 0:
+		return VerdictAhead, UpdateVariant
+	case c < 0:
+		return VerdictOutdated, UpdateNone
+	}
+	return VerdictEqual, UpdateNone
+}
@@ -1,7 +1,43 @@
 package tag
 
+import "fmt"
+
 const (
 	Ignore = iota
 	KeepMajor
 	KeepMinor
+	KeepPatch
 )
+
+// keptComponents returns the number of leading version components a keep
+// level keeps: 1 for KeepMajor, 2 for KeepMinor, 3 for KeepPatch; 0 for
+// Ignore
+func keptComponents(keepLevel int) int {
+
+	switch keepLevel {
+	case KeepMajor:
+		return 1
+	case KeepMinor:
+		return 2
+	case KeepPatch:
+		return 3
+	}
+	return 0
+}
+
+// ParseKeepLevel parses "major", "minor" or "patch" into KeepMajor,
+// KeepMinor or KeepPatch. An empty level yields Ignore.
+func ParseKeepLevel(level string) (int, error) {
+
+	switch level {
+	case "":
+		return Ignore, nil
+	case "major":
+		return KeepMajor, nil
+	case "minor":
+		return KeepMinor, nil
+	case "patch":
+		return KeepPatch, nil
+	}
+	return Ignore, fmt.Errorf("unknown keep level %q", level)
+}
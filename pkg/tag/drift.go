@@ -0,0 +1,62 @@
+package tag
+
+// Drift tells how far base is behind the tags of a list: Releases counts
+// the newer versions, Majors and Minors the newer major and minor lines
+// among them ("1.21.3" => "1.22.0", "1.23.4", "2.0.1": 3 releases, 1 major,
+// 3 minors). Libyears is the age difference between base and the newest
+// tag in years, if HasLibyears; it is derived from the creation dates of
+// the images and thus is not filled by List.Drift.
+type Drift struct {
+	Releases int
+	Majors   int
+	Minors   int
+
+	Libyears    float64
+	HasLibyears bool
+}
+
+// Drift computes the Drift of base against the tags of the list. Floating
+// tags ("3.19") and tags with the same version as base (rebuilds,
+// variants) are not counted. The lines are derived from the Keep filters of
+// the scheme of base.
+func (tags List) Drift(base *Tag) Drift {
+
+	drift := Drift{}
+	keepMinor := base.Scheme.Keep(base, KeepMinor)
+	keepMajor := base.Scheme.Keep(base, KeepMajor)
+
+	seen := map[string]bool{}
+	majors, minors := List{}, List{}
+
+	for _, t := range tags {
+		if verdict, _ := Compare(base, t); verdict != VerdictAhead {
+			continue
+		}
+		if t.IsFloating() || t.Version.Compare(base.Version) <= 0 || seen[t.Version.String()] {
+			continue
+		}
+		seen[t.Version.String()] = true
+		drift.Releases++
+
+		if !keepMinor(t) && !minors.hasLine(t, KeepMinor) {
+			minors = append(minors, t)
+		}
+		if !keepMajor(t) && !majors.hasLine(t, KeepMajor) {
+			majors = append(majors, t)
+		}
+	}
+
+	drift.Majors, drift.Minors = len(majors), len(minors)
+	return drift
+}
+
+// hasLine reports whether one of the tags of the list is on the same line
+// as t, according to keepLevel
+func (tags List) hasLine(t *Tag, keepLevel int) bool {
+	for _, o := range tags {
+		if o.Scheme.Keep(o, keepLevel)(t) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,28 @@
+package tag
+
+import (
+	"testing"
+)
+
+func TestDrift(t *testing.T) {
+
+	in := []string{"1.20.9", "1.21", "1.21.2", "1.21.3", "1.21.13", "1.22.0", "1.23.4", "1.23.1", "2.0.1"}
+	tags := NewFromStrings(in, SemVerScheme, nil, ApplyFilterList(nil))
+
+	fixtures := [...]struct {
+		Base     string
+		Expected Drift
+	}{
+		{"1.21.3", Drift{Releases: 5, Majors: 1, Minors: 3}},
+		{"1.23.1", Drift{Releases: 2, Majors: 1, Minors: 1}},
+		{"1.20.9", Drift{Releases: 7, Majors: 1, Minors: 4}},
+		{"2.0.1", Drift{}},
+	}
+
+	for _, f := range fixtures {
+		base, _ := Parse(f.Base)
+		if actual := tags.Drift(base); actual != f.Expected {
+			t.Fatalf("%q: expected: %+v, actual: %+v", f.Base, f.Expected, actual)
+		}
+	}
+}
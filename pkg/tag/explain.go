@@ -0,0 +1,58 @@
+package tag
+
+// NamedFilter is a FilterFunc with a name, to be reported by Explain
+type NamedFilter struct {
+	Name   string
+	Filter FilterFunc
+}
+
+// FilterOutcome records the outcome of a single NamedFilter
+type FilterOutcome struct {
+	Name string
+	Kept bool
+}
+
+// Decision records how a single tag name was processed: filtered out by its
+// name, not parsed according to the scheme or the outcome of each filter.
+type Decision struct {
+	Name       string
+	NameKept   bool
+	Tag        *Tag
+	ParseError error
+	Filters    []FilterOutcome
+	Kept       bool
+}
+
+// Explain processes tags like NewFromStrings does, but records a Decision
+// per tag instead of dropping it. All filters are evaluated for each tag,
+// not only the ones up to the first which filters it out.
+func Explain(tags []string, scheme Scheme, nameFilter NameFilterFunc, filters []NamedFilter) []Decision {
+
+	decisions := make([]Decision, 0, len(tags))
+
+	for _, name := range tags {
+
+		d := Decision{Name: name, NameKept: nameFilter == nil || nameFilter(name)}
+		if !d.NameKept {
+			decisions = append(decisions, d)
+			continue
+		}
+
+		d.Tag, d.ParseError = scheme.Parse(name)
+		if d.ParseError != nil {
+			decisions = append(decisions, d)
+			continue
+		}
+
+		d.Kept = true
+		for _, f := range filters {
+			kept := f.Filter(d.Tag)
+			d.Filters = append(d.Filters, FilterOutcome{Name: f.Name, Kept: kept})
+			d.Kept = d.Kept && kept
+		}
+
+		decisions = append(decisions, d)
+	}
+
+	return decisions
+}
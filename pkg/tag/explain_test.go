@@ -0,0 +1,52 @@
+package tag
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExplain(t *testing.T) {
+
+	base, _ := Parse("1.21.3")
+	in := []string{"1.21.13", "1.22.0-rc.1", "sha-abc123", "buildcache", "2.0.0"}
+
+	nameFilter := func(name string) bool { return !strings.HasPrefix(name, "sha-") }
+	filters := []NamedFilter{
+		{"prerelease", DefaultPrereleasePolicy().Filter(base)},
+		{"keep", SemVerScheme.Keep(base, KeepMajor)},
+	}
+
+	fixtures := [...]struct {
+		Name        string
+		NameKept    bool
+		Parsed      bool
+		Kept        bool
+		FiltersKept []bool
+	}{
+		{"1.21.13", true, true, true, []bool{true, true}},
+		{"1.22.0-rc.1", true, true, false, []bool{false, true}},
+		{"sha-abc123", false, false, false, nil},
+		{"buildcache", true, false, false, nil},
+		{"2.0.0", true, true, false, []bool{true, false}},
+	}
+
+	decisions := Explain(in, SemVerScheme, nameFilter, filters)
+	if len(decisions) != len(fixtures) {
+		t.Fatalf("expected: %d, actual: %d", len(fixtures), len(decisions))
+	}
+
+	for i, f := range fixtures {
+		d := decisions[i]
+		if d.Name != f.Name || d.NameKept != f.NameKept || (d.Tag != nil) != f.Parsed || d.Kept != f.Kept {
+			t.Fatalf("%q: expected: %v, actual: %v", f.Name, f, d)
+		}
+		if len(d.Filters) != len(f.FiltersKept) {
+			t.Fatalf("%q: expected: %d, actual: %d", f.Name, len(f.FiltersKept), len(d.Filters))
+		}
+		for j := range d.Filters {
+			if d.Filters[j].Kept != f.FiltersKept[j] {
+				t.Fatalf("%q %s: expected: %t, actual: %t", f.Name, d.Filters[j].Name, f.FiltersKept[j], d.Filters[j].Kept)
+			}
+		}
+	}
+}
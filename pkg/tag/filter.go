@@ -2,16 +2,15 @@ package tag
 
 import (
 	"math"
-	"strings"
 
 	"github.com/Masterminds/semver/v3"
 )
 
-// FilterFunc describes a function which filters out semver-versions:
+// FilterFunc describes a function which filters out tags:
 // a return value of
 // * true - the entry stays
 // * false - the entry gets filtered out
-type FilterFunc func(*semver.Version) bool
+type FilterFunc func(*Tag) bool
 
 // HugeVersionHeuristicFilter generates a tag.FilterFunc to filter out
 // unnatural gaps the version numbering in some repos which use a date-format:
@@ -20,15 +19,20 @@ type FilterFunc func(*semver.Version) bool
 //
 // n == 1000 seems to be a major, "unnatural" jump in
 // the major version. lets call it a "heuristic".
+//
+// note: tags which are recognized as calendar versions are kept apart from
+// semantic versions via SchemeFilter, the heuristic only catches what is
+// left, eg "2021053" or "1000".
 func HugeVersionHeuristicFilter(a *semver.Version, limit int) FilterFunc {
 
-	filter := func(b *semver.Version) bool {
+	filter := func(t *Tag) bool {
 
 		if limit < 0 {
 			return false
 		}
 
-		if b.Major() <= a.Major() {
+		b := t.SemVer()
+		if b == nil || a == nil || b.Major() <= a.Major() {
 			return true
 		}
 
@@ -46,29 +50,44 @@ func HugeVersionHeuristicFilter(a *semver.Version, limit int) FilterFunc {
 }
 
 // ConstraintFilter generates a tag.FilterFunc based on a semver.Constraint.
-// The Check() method already has the same interface as required for FilterFunc,
-// hence, just return the function.
+// The constraint is checked against the version without the pre-release
+// part: semver.Constraints never match pre-releases, but for container
+// images that part mostly names a variant ("-alpine"). Numeric versions are
+// checked with their first three components, tags of other schemes never
+// match.
 func ConstraintFilter(c *semver.Constraints) FilterFunc {
 
-	return c.Check
+	return func(t *Tag) bool {
+		v := asSemVer(t.Version)
+		return v != nil && c.Check(v)
+	}
 }
 
-// IgnoreBetaVersions filters all labels which start with
-// * "rc" - for release-candidate
-// * "beta" - for beta-versions
-// * "alpha" - for alpha-versions
-func IgnoreBetaVersions(v *semver.Version) bool {
-
-	p := v.Prerelease()
+// VariantFilter generates a tag.FilterFunc which only keeps tags of the
+// variant named like the given variant. The version or the codename of the
+// variant might differ: "alpine3.18" matches "alpine3.19", "slim-bullseye"
+// matches "slim-bookworm".
+func VariantFilter(v Variant) FilterFunc {
 
-	switch {
-	case strings.HasPrefix(p, "rc"):
-	case strings.HasPrefix(p, "beta"):
-	case strings.HasPrefix(p, "alpha"):
-		return false
+	return func(t *Tag) bool {
+		return t.Variant.Name == v.Name && t.Variant.Distro == v.Distro
 	}
+}
+
+// SchemeFilter generates a tag.FilterFunc which only keeps tags following
+// the scheme of base. Calendar versions need to share the prefix as well,
+// "jammy-20240427" is not comparable to "noble-20240423".
+func SchemeFilter(base *Tag) FilterFunc {
 
-	return true
+	return func(t *Tag) bool {
+		if t.Scheme != base.Scheme {
+			return false
+		}
+		if base.Scheme == CalVerScheme {
+			return t.Variant.String() == base.Variant.String()
+		}
+		return true
+	}
 }
 
 // ApplyFilterList returns a tag.FilterFunc which executes all filters in
@@ -76,9 +95,9 @@ func IgnoreBetaVersions(v *semver.Version) bool {
 // the list. Thus, logical "AND" is applied here.
 func ApplyFilterList(list []FilterFunc) FilterFunc {
 
-	filter := func(v *semver.Version) bool {
+	filter := func(t *Tag) bool {
 		for _, f := range list {
-			if !f(v) {
+			if !f(t) {
 				return false
 			}
 		}
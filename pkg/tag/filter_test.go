@@ -0,0 +1,92 @@
+package tag
+
+import (
+	"testing"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+func TestConstraintFilter(t *testing.T) {
+
+	c, err := semver.NewConstraint(">=1.24 <1.27")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	filter := ConstraintFilter(c)
+
+	fixtures := [...]struct {
+		Tag      string
+		Expected bool
+	}{
+		{"1.24.2", true},
+		{"1.26.4-alpine3.20", true},
+		{"1.27.0", false},
+		{"1.23.9", false},
+		{"1.25.0.3", true},
+		{"jammy-20240427", false},
+	}
+
+	for _, f := range fixtures {
+		tag, err := Parse(f.Tag)
+		if err != nil {
+			t.Fatalf("%q: unexpected error: %s", f.Tag, err)
+		}
+		if actual := filter(tag); actual != f.Expected {
+			t.Fatalf("%q: expected: %t, actual: %t", f.Tag, f.Expected, actual)
+		}
+	}
+}
+
+func TestConstrainable(t *testing.T) {
+
+	fixtures := [...]struct {
+		Tag      string
+		Expected bool
+	}{
+		{"1.24.2", true},
+		{"4.8.1.2", true},
+		{"20240427", false},
+		{"jammy-20240427", false},
+		{"bookworm", false},
+	}
+
+	for _, f := range fixtures {
+		tag, err := Parse(f.Tag)
+		if err != nil {
+			t.Fatalf("%q: unexpected error: %s", f.Tag, err)
+		}
+		if actual := tag.Constrainable(); actual != f.Expected {
+			t.Fatalf("%q: expected: %t, actual: %t", f.Tag, f.Expected, actual)
+		}
+	}
+}
+
+func TestSemVerKeepFilter(t *testing.T) {
+
+	in := []string{"1.25.0", "1.25.3", "1.26.1", "2.0.0"}
+
+	fixtures := [...]struct {
+		Base     string
+		Keep     int
+		Expected int
+	}{
+		{"1.25.0", KeepPatch, 1},
+		{"1.25", KeepPatch, 2},
+		{"v1.25", KeepPatch, 2},
+		{"1.25.0", KeepMinor, 2},
+		{"1", KeepMinor, 3},
+		{"1.25.0", KeepMajor, 3},
+		{"1.25.0", Ignore, 4},
+	}
+
+	for _, f := range fixtures {
+		base, err := SemVerScheme.Parse(f.Base)
+		if err != nil {
+			t.Fatalf("%q: unexpected error: %s", f.Base, err)
+		}
+		tags := NewFromStrings(in, SemVerScheme, nil, SemVerScheme.Keep(base, f.Keep))
+		if f.Expected != len(tags) {
+			t.Fatalf("%s keep %d: expected: %d, actual: %d", f.Base, f.Keep, f.Expected, len(tags))
+		}
+	}
+}
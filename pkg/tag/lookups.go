@@ -0,0 +1,49 @@
+package tag
+
+// MaxLookups caps the lookups (platforms, signatures, referrers) a single
+// List.Require… call runs: each costs at least one request. The tags beyond
+// are treated as if their lookup failed.
+const MaxLookups = 20
+
+// lookupFunc decides about t, based on a costly lookup: whether t passes
+// and, if not, whether it is kept nonetheless. If known is false, the
+// lookup is not run (MaxLookups is reached) and t is to be treated as if
+// the lookup failed.
+type lookupFunc func(t *Tag, known bool) (passed, keep bool)
+
+// require runs check on the tags of the list (sorted, newest first) ahead
+// of base until limit tags passed it (all, if limit is 0); only the first
+// MaxLookups of them are looked up. The tags not ahead of base, and those
+// beyond limit, are kept as they are.
+func (tags List) require(base *Tag, limit int, check lookupFunc) List {
+
+	checked := make(List, 0, len(tags))
+	n, lookups := 0, 0
+	done := false
+
+	for _, t := range tags {
+
+		if !done {
+			verdict, _ := Compare(base, t)
+			done = verdict != VerdictAhead || (limit > 0 && n >= limit)
+		}
+		if done {
+			checked = append(checked, t)
+			continue
+		}
+
+		known := lookups < MaxLookups
+		if known {
+			lookups++
+		}
+		passed, keep := check(t, known)
+		if passed {
+			n++
+		}
+		if passed || keep {
+			checked = append(checked, t)
+		}
+	}
+
+	return checked
+}
@@ -0,0 +1,45 @@
+package tag
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestRequireMaxLookups(t *testing.T) {
+
+	in := []string{}
+	for i := 0; i < 3*MaxLookups; i++ {
+		in = append(in, fmt.Sprintf("1.%d.0", i))
+	}
+	base, _ := SemVerScheme.Parse("1.0.0")
+
+	fixtures := [...]struct {
+		Mark            bool
+		ExpectedTags    int
+		ExpectedLookups int
+	}{
+		{false, 1, MaxLookups},
+		{true, 3 * MaxLookups, MaxLookups},
+	}
+
+	for _, f := range fixtures {
+
+		lookups := 0
+		none := func(t *Tag) []string {
+			lookups++
+			return nil
+		}
+
+		tags := NewFromStrings(in, SemVerScheme, nil, VariantFilter(Variant{}))
+		tags.Sort()
+		tags.Reverse()
+
+		checked := tags.RequirePlatforms(base, []string{"linux/amd64"}, none, 1, f.Mark)
+		if len(checked) != f.ExpectedTags {
+			t.Fatalf("mark %t: expected: %d, actual: %d", f.Mark, f.ExpectedTags, len(checked))
+		}
+		if lookups != f.ExpectedLookups {
+			t.Fatalf("mark %t: expected: %d, actual: %d", f.Mark, f.ExpectedLookups, lookups)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package tag
+
+import (
+	"regexp"
+)
+
+// NameFilterFunc describes a function which filters out tags by their
+// name, before they are parsed. See FilterFunc for the return values.
+type NameFilterFunc func(string) bool
+
+// NamePatterns filters tags by their name via regular expressions. Repos
+// tend to publish tags like "sha-abc123", "pr-1234" or "buildcache" which are
+// no releases but might parse as versions nevertheless.
+//
+// If Include is not empty, a name has to match at least one of its patterns.
+// A name matching one of the patterns in Exclude is filtered out. The
+// patterns are not anchored implicitly: "^pr-" vs "pr-".
+type NamePatterns struct {
+	Include []*regexp.Regexp
+	Exclude []*regexp.Regexp
+}
+
+// NewNamePatterns compiles the patterns of include and exclude into
+// NamePatterns
+func NewNamePatterns(include, exclude []string) (*NamePatterns, error) {
+
+	p := &NamePatterns{}
+	var err error
+
+	if p.Include, err = compilePatterns(include); err != nil {
+		return nil, err
+	}
+	if p.Exclude, err = compilePatterns(exclude); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func compilePatterns(patterns []string) ([]*regexp.Regexp, error) {
+
+	list := make([]*regexp.Regexp, 0, len(patterns))
+	for _, pattern := range patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, re)
+	}
+	return list, nil
+}
+
+// Match reports whether the tag name passes p. A nil p lets every name
+// pass.
+func (p *NamePatterns) Match(name string) bool {
+
+	if p == nil {
+		return true
+	}
+	if len(p.Include) > 0 && !matchAny(p.Include, name) {
+		return false
+	}
+	return !matchAny(p.Exclude, name)
+}
@@ -0,0 +1,38 @@
+package tag
+
+import (
+	"testing"
+)
+
+func TestNamePatterns(t *testing.T) {
+
+	in := []string{"1.2.3", "1.3.0", "sha-abc123", "pr-1234", "latest-arm64", "buildcache", "v2.0.0", "1.3.0-arm64"}
+
+	fixtures := [...]struct {
+		Include  []string
+		Exclude  []string
+		Expected []string
+	}{
+		{nil, nil, []string{"1.2.3", "1.3.0-arm64", "1.3.0", "v2.0.0"}},
+		{nil, []string{"-arm64$"}, []string{"1.2.3", "1.3.0", "v2.0.0"}},
+		{[]string{`^\d`}, nil, []string{"1.2.3", "1.3.0-arm64", "1.3.0"}},
+		{[]string{`^\d`}, []string{"arm64", `^1\.2`}, []string{"1.3.0"}},
+	}
+
+	for i, f := range fixtures {
+		p, err := NewNamePatterns(f.Include, f.Exclude)
+		if err != nil {
+			t.Fatalf("fixture %d: unexpected error: %s", i, err)
+		}
+		tags := NewFromStrings(in, SemVerScheme, p.Match, ApplyFilterList(nil))
+		tags.Sort()
+		if len(tags) != len(f.Expected) {
+			t.Fatalf("fixture %d: expected: %d, actual: %d", i, len(f.Expected), len(tags))
+		}
+		for j := range tags {
+			if tags[j].String() != f.Expected[j] {
+				t.Fatalf("fixture %d: expected: %q, actual: %q", i, f.Expected[j], tags[j].String())
+			}
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package tag
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Numeric is a version made of an arbitrary number of numeric components,
+// like "4.8.1.2" or "1.2.3.4.5". Semantic versions are limited to three
+// components, tags with more components are not accepted by semver.
+type Numeric struct {
+	Segments []uint64
+}
+
+// reNumeric matches "1.2.3.4", "v1.2.3.4-suffix" or "2.3.0_7", where "_7"
+// denotes the revision
+var reNumeric = regexp.MustCompile(`^v?(\d+(?:\.\d+)*)(_\d+)?(?:-(.+))?$`)
+
+var errNoNumeric = errors.New("not a numeric version")
+
+// ParseNumeric parses s into a Numeric version plus the suffix (after "-").
+// A revision given with "_" ("2.3.0_7") is returned as part of the suffix
+// ("7-…"), see Tag.Revision.
+func ParseNumeric(s string) (v Numeric, suffix string, err error) {
+
+	m := reNumeric.FindStringSubmatch(s)
+	if m == nil {
+		return v, suffix, errNoNumeric
+	}
+
+	for _, f := range strings.Split(m[1], ".") {
+		n, err := strconv.ParseUint(f, 10, 64)
+		if err != nil {
+			return Numeric{}, "", err
+		}
+		v.Segments = append(v.Segments, n)
+	}
+
+	suffix = m[3]
+	if rev := strings.TrimPrefix(m[2], "_"); rev != "" {
+		suffix = strings.Trim(rev+sepVariantParts+suffix, sepVariantParts)
+	}
+
+	return v, suffix, nil
+}
+
+// Compare compares v and o component by component, missing components
+// count as 0: "1.2" == "1.2.0.0"
+func (v Numeric) Compare(o Version) int {
+
+	ov, ok := o.(Numeric)
+	if !ok {
+		return 0
+	}
+
+	for i := 0; i < max(len(v.Segments), len(ov.Segments)); i++ {
+		if c := compareUint(numericSegment(v.Segments, i), numericSegment(ov.Segments, i)); c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+// Contains reports if o matches v in all the components given in v:
+// "4.8" contains "4.8.1.2"
+func (v Numeric) Contains(o Version) bool {
+
+	ov, ok := o.(Numeric)
+	return ok && v.sharesPrefix(ov, len(v.Segments))
+}
+
+// String satisfies the Stringer interface
+func (v Numeric) String() string {
+
+	parts := make([]string, len(v.Segments))
+	for i, n := range v.Segments {
+		parts[i] = strconv.FormatUint(n, 10)
+	}
+	return strings.Join(parts, ".")
+}
+
+// sharesPrefix reports if the first n components of v and o are equal
+func (v Numeric) sharesPrefix(o Numeric, n int) bool {
+
+	for i := 0; i < n; i++ {
+		if numericSegment(v.Segments, i) != numericSegment(o.Segments, i) {
+			return false
+		}
+	}
+	return true
+}
+
+func numericSegment(segments []uint64, i int) uint64 {
+	if i < len(segments) {
+		return segments[i]
+	}
+	return 0
+}
+
+func compareUint(a, b uint64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+type numericScheme struct{}
+
+func (numericScheme) Name() string { return "numeric" }
+
+func (numericScheme) Parse(s string) (*Tag, error) {
+
+	v, suffix, err := ParseNumeric(s)
+	if err != nil {
+		return nil, err
+	}
+
+	return newTag(NumericScheme, v, suffix, s), nil
+}
+
+// Keep only keeps numeric versions sharing the components of base up to
+// the given keep level: KeepMajor keeps the first component, KeepMinor the
+// first two, KeepPatch the first three - as far as base has them ("1.25"
+// keeps "1.25.x" at KeepPatch).
+func (numericScheme) Keep(base *Tag, keepLevel int) FilterFunc {
+
+	bv, _ := base.Version.(Numeric)
+	n := min(keptComponents(keepLevel), len(bv.Segments))
+	return func(t *Tag) bool {
+		v, ok := t.Version.(Numeric)
+		return ok && bv.sharesPrefix(v, n)
+	}
+}
@@ -0,0 +1,72 @@
+package tag
+
+import (
+	"testing"
+)
+
+func TestParseNumeric(t *testing.T) {
+
+	fixtures := [...]struct {
+		Tag            string
+		ExpectedScheme Scheme
+		ExpectedString string
+	}{
+		{"4.8.1.2", NumericScheme, "4.8.1.2"},
+		{"v1.2.3.4", NumericScheme, "v1.2.3.4"},
+		{"1.2.3.4.5-windowsservercore", NumericScheme, "1.2.3.4.5-windowsservercore"},
+		{"1.2.3", SemVerScheme, "1.2.3"},
+	}
+
+	for _, f := range fixtures {
+		tag, err := Parse(f.Tag)
+		if err != nil {
+			t.Fatalf("%q: unexpected error: %s", f.Tag, err)
+		}
+		if f.ExpectedScheme != tag.Scheme {
+			t.Fatalf("expected: %q, actual: %q", f.ExpectedScheme.Name(), tag.Scheme.Name())
+		}
+		if f.ExpectedString != tag.String() {
+			t.Fatalf("expected: %q, actual: %q", f.ExpectedString, tag.String())
+		}
+	}
+}
+
+func TestSortNumeric(t *testing.T) {
+
+	in := []string{"4.8.1.10", "4.8.1.2", "4.8.2", "4.8.1.2-ltsc2022", "4.7.9.9.9"}
+	expected := []string{"4.7.9.9.9", "4.8.1.2", "4.8.1.2-ltsc2022", "4.8.1.10", "4.8.2"}
+
+	tags := NewFromStrings(in, NumericScheme, nil, ApplyFilterList(nil))
+	tags.Sort()
+
+	for i := range expected {
+		if expected[i] != tags[i].String() {
+			t.Fatalf("expected: %q, actual: %q", expected[i], tags[i].String())
+		}
+	}
+}
+
+func TestNumericKeepFilter(t *testing.T) {
+
+	in := []string{"4.8.1.3", "4.8.2.0", "4.9.0.0", "5.0.0.0"}
+
+	fixtures := [...]struct {
+		Base     string
+		Keep     int
+		Expected int
+	}{
+		{"4.8.1.2", KeepMajor, 3},
+		{"4.8.1.2", KeepMinor, 2},
+		{"4.8.1.2", KeepPatch, 1},
+		{"4.8", KeepPatch, 2},
+		{"4", KeepMinor, 3},
+	}
+
+	for _, f := range fixtures {
+		base, _ := NumericScheme.Parse(f.Base)
+		tags := NewFromStrings(in, NumericScheme, nil, NumericScheme.Keep(base, f.Keep))
+		if f.Expected != len(tags) {
+			t.Fatalf("%s keep %d: expected: %d, actual: %d", f.Base, f.Keep, f.Expected, len(tags))
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package tag
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// PlatformFunc returns the platforms ("linux/arm64/v8") of the image t
+// points to; nil if unknown
+type PlatformFunc func(t *Tag) []string
+
+// ParsePlatforms parses a comma separated list of platforms:
+// "linux/arm64,linux/amd64". Each platform is given as "os/arch" or
+// "os/arch/variant".
+func ParsePlatforms(s string) ([]string, error) {
+
+	platforms := []string{}
+	for _, p := range strings.Split(s, ",") {
+		p = strings.TrimSpace(p)
+		parts := strings.Split(p, "/")
+		if len(parts) < 2 || len(parts) > 3 || slices.Contains(parts, "") {
+			return nil, fmt.Errorf("%q: expected os/arch[/variant]", p)
+		}
+		platforms = append(platforms, p)
+	}
+	return platforms, nil
+}
+
+// PlatformMatches reports whether the platform "have" of an image satisfies
+// the required one: os and arch are the same, the variant only counts if
+// required names one. "arm64" without variant is "arm64/v8".
+func PlatformMatches(required, have string) bool {
+
+	r, h := strings.Split(required, "/"), strings.Split(have, "/")
+	if len(r) < 2 || len(h) < 2 || r[0] != h[0] || r[1] != h[1] {
+		return false
+	}
+	if len(r) < 3 {
+		return true
+	}
+	variant := ""
+	if len(h) > 2 {
+		variant = h[2]
+	}
+	if variant == "" && h[1] == "arm64" {
+		variant = "v8"
+	}
+	return r[2] == variant
+}
+
+// MissingPlatforms returns the required platforms which are not matched by
+// any of the platforms in have
+func MissingPlatforms(required, have []string) []string {
+
+	missing := []string{}
+	for _, r := range required {
+		found := false
+		for _, h := range have {
+			if PlatformMatches(r, h) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, r)
+		}
+	}
+	return missing
+}
+
+// RequirePlatforms checks the tags of the list (sorted, newest first) ahead
+// of base against the required platforms. Tags missing any of them are
+// dropped or, if mark is set, kept with their MissingPlatforms; either way
+// the check goes on with the next tag until limit tags providing all
+// required platforms are found (all tags ahead of base are checked, if
+// limit is 0), but at most MaxLookups tags are looked up. Tags whose
+// platforms are unknown (the lookup failed) do not provide any of the
+// required platforms.
+func (tags List) RequirePlatforms(base *Tag, required []string, platforms PlatformFunc, limit int, mark bool) List {
+
+	return tags.require(base, limit, func(t *Tag, known bool) (bool, bool) {
+		t.Platforms = nil
+		if known {
+			t.Platforms = platforms(t)
+		}
+		t.MissingPlatforms = MissingPlatforms(required, t.Platforms)
+		return len(t.MissingPlatforms) == 0, mark
+	})
+}
+
+// Complete returns the tags of the list which do not miss any required
+// platform, see RequirePlatforms
+func (tags List) Complete() List {
+
+	complete := make(List, 0, len(tags))
+	for _, t := range tags {
+		if len(t.MissingPlatforms) == 0 {
+			complete = append(complete, t)
+		}
+	}
+	return complete
+}
@@ -0,0 +1,110 @@
+package tag
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPlatformMatches(t *testing.T) {
+
+	fixtures := [...]struct {
+		Required string
+		Have     string
+		Expected bool
+	}{
+		{"linux/amd64", "linux/amd64", true},
+		{"linux/arm64", "linux/arm64/v8", true},
+		{"linux/arm64/v8", "linux/arm64", true},
+		{"linux/arm/v7", "linux/arm/v6", false},
+		{"linux/arm", "linux/arm/v6", true},
+		{"linux/amd64", "windows/amd64", false},
+		{"linux/amd64", "linux", false},
+	}
+
+	for _, f := range fixtures {
+		if actual := PlatformMatches(f.Required, f.Have); actual != f.Expected {
+			t.Fatalf("%s ~ %s: expected: %t, actual: %t", f.Required, f.Have, f.Expected, actual)
+		}
+	}
+}
+
+func TestParsePlatforms(t *testing.T) {
+
+	fixtures := [...]struct {
+		In          string
+		Expected    []string
+		ExpectedErr bool
+	}{
+		{"linux/arm64,linux/amd64", []string{"linux/arm64", "linux/amd64"}, false},
+		{"linux/arm64, linux/arm/v7", []string{"linux/arm64", "linux/arm/v7"}, false},
+		{"linux", nil, true},
+		{"linux/", nil, true},
+		{"linux/arm64,", nil, true},
+	}
+
+	for _, f := range fixtures {
+		actual, err := ParsePlatforms(f.In)
+		if (err != nil) != f.ExpectedErr {
+			t.Fatalf("%q: expected error: %t, actual: %v", f.In, f.ExpectedErr, err)
+		}
+		if strings.Join(actual, "|") != strings.Join(f.Expected, "|") {
+			t.Fatalf("expected: %q, actual: %q", f.Expected, actual)
+		}
+	}
+}
+
+func TestRequirePlatforms(t *testing.T) {
+
+	in := []string{"3.18.6", "3.19.0", "3.19.1", "3.20.0"}
+	platforms := map[string][]string{
+		"3.20.0": {"linux/amd64"},
+		"3.19.1": {"linux/amd64", "linux/arm64/v8"},
+		"3.19.0": {"linux/amd64", "linux/arm64/v8"},
+	}
+	required := []string{"linux/amd64", "linux/arm64"}
+
+	fixtures := [...]struct {
+		Base            string
+		Limit           int
+		Mark            bool
+		Expected        []string
+		ExpectedChecked int
+	}{
+		{"3.18.0", 1, false, []string{"3.19.1", "3.19.0", "3.18.6"}, 2},
+		{"3.18.0", 1, true, []string{"3.20.0 (linux/arm64)", "3.19.1", "3.19.0", "3.18.6"}, 2},
+		{"3.18.0", 0, false, []string{"3.19.1", "3.19.0"}, 4},
+		{"3.18.0", 0, true, []string{"3.20.0 (linux/arm64)", "3.19.1", "3.19.0", "3.18.6 (linux/amd64, linux/arm64)"}, 4},
+		{"3.19.0", 0, false, []string{"3.19.1", "3.19.0", "3.18.6"}, 2},
+		{"3.20.0", 1, false, []string{"3.20.0", "3.19.1", "3.19.0", "3.18.6"}, 0},
+	}
+
+	for _, f := range fixtures {
+
+		checked := 0
+		platformsOf := func(t *Tag) []string {
+			checked++
+			return platforms[t.String()]
+		}
+
+		tags := NewFromStrings(in, SemVerScheme, nil, VariantFilter(Variant{}))
+		tags.Sort()
+		tags.Reverse()
+
+		base, _ := SemVerScheme.Parse(f.Base)
+		actual := []string{}
+		for _, t := range tags.RequirePlatforms(base, required, platformsOf, f.Limit, f.Mark) {
+			s := t.String()
+			if len(t.MissingPlatforms) > 0 {
+				s += " (" + strings.Join(t.MissingPlatforms, ", ") + ")"
+			}
+			actual = append(actual, s)
+		}
+
+		if strings.Join(actual, "|") != strings.Join(f.Expected, "|") {
+			t.Fatalf("base %s, limit %d, mark %t: expected: %q, actual: %q", f.Base, f.Limit, f.Mark, f.Expected, actual)
+		}
+		if checked != f.ExpectedChecked {
+			t.Fatalf("base %s, limit %d, mark %t: expected: %d, actual: %d", f.Base, f.Limit, f.Mark, f.ExpectedChecked, checked)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package tag
+
+import (
+	"regexp"
+	"strings"
+)
+
+// PrereleaseChannels lists the pre-release channels which are recognized in
+// the variant of a tag: "1.2.0-rc.1", "2.0.0-beta2", "3.1-nightly",
+// "1.0-SNAPSHOT"
+var PrereleaseChannels = []string{
+	"alpha", "beta", "rc", "pre", "preview",
+	"dev", "nightly", "snapshot", "canary", "next", "unstable",
+}
+
+// reChannelPart matches a part of a variant which names a channel,
+// optionally followed by a number: "rc", "rc.1", "beta2", "dev_3"
+var reChannelPart = regexp.MustCompile(`^([a-zA-Z]+)[._]?[\d.]*$`)
+
+// PrereleasePolicy decides which pre-release channels of a repo are
+// considered. Tags of a channel matching Allow are kept, tags of a channel
+// matching Exclude are filtered out. If Exclude is empty, all channels not
+// matching Allow are filtered out. Tags without a channel are always kept.
+//
+// The patterns are matched against the whole, lower-cased channel name
+// ("rc" for "1.2.0-rc.1"); channels beyond PrereleaseChannels are
+// recognized if they match one of the patterns.
+type PrereleasePolicy struct {
+	Allow   []*regexp.Regexp
+	Exclude []*regexp.Regexp
+}
+
+// NewPrereleasePolicy compiles the patterns of allow and exclude into a
+// PrereleasePolicy
+func NewPrereleasePolicy(allow, exclude []string) (*PrereleasePolicy, error) {
+
+	p := &PrereleasePolicy{}
+	var err error
+
+	if p.Allow, err = compileChannelPatterns(allow); err != nil {
+		return nil, err
+	}
+	if p.Exclude, err = compileChannelPatterns(exclude); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// DefaultPrereleasePolicy returns a PrereleasePolicy which filters out all
+// PrereleaseChannels
+func DefaultPrereleasePolicy() *PrereleasePolicy {
+	p, _ := NewPrereleasePolicy(nil, nil)
+	return p
+}
+
+func compileChannelPatterns(patterns []string) ([]*regexp.Regexp, error) {
+
+	anchored := make([]string, 0, len(patterns))
+	for _, pattern := range patterns {
+		anchored = append(anchored, `^(?:`+pattern+`)$`)
+	}
+	return compilePatterns(anchored)
+}
+
+// Channel returns the pre-release channel of t, one of PrereleaseChannels;
+// "" if t is a regular release
+func Channel(t *Tag) string {
+	var p *PrereleasePolicy
+	return p.Channel(t)
+}
+
+// Channel returns the pre-release channel of t, one of PrereleaseChannels
+// or a channel matching the patterns of p; "" if t is a regular release
+func (p *PrereleasePolicy) Channel(t *Tag) string {
+
+	for _, part := range strings.Split(t.Variant.String(), sepVariantParts) {
+		m := reChannelPart.FindStringSubmatch(part)
+		if m == nil {
+			continue
+		}
+		name := strings.ToLower(m[1])
+		for _, c := range PrereleaseChannels {
+			if name == c {
+				return name
+			}
+		}
+		if p != nil && (matchAny(p.Allow, name) || matchAny(p.Exclude, name)) {
+			return name
+		}
+	}
+	return ""
+}
+
+// Filter generates a tag.FilterFunc which applies the policy. If base itself
+// is a pre-release, tags of the same channel are kept as well: who follows
+// "rc" wants to see the next "rc".
+func (p *PrereleasePolicy) Filter(base *Tag) FilterFunc {
+
+	own := p.Channel(base)
+
+	return func(t *Tag) bool {
+		c := p.Channel(t)
+		switch {
+		case c == "", c == own:
+			return true
+		case matchAny(p.Allow, c):
+			return true
+		case len(p.Exclude) == 0:
+			return false
+		}
+		return !matchAny(p.Exclude, c)
+	}
+}
+
+func matchAny(list []*regexp.Regexp, s string) bool {
+	for _, re := range list {
+		if re.MatchString(s) {
+			return true
+		}
+	}
+	return false
+}
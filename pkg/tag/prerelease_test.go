@@ -0,0 +1,78 @@
+package tag
+
+import (
+	"testing"
+)
+
+func TestPrereleaseChannel(t *testing.T) {
+
+	p := DefaultPrereleasePolicy()
+
+	fixtures := [...]struct {
+		Tag      string
+		Expected string
+	}{
+		{"1.2.0", ""},
+		{"1.2.0-alpine3.19", ""},
+		{"1.2.0-alpha.1", "alpha"},
+		{"1.2.0-beta2", "beta"},
+		{"1.2.0-rc.1", "rc"},
+		{"1.2.0-rc1-alpine", "rc"},
+		{"1.2.0-dev", "dev"},
+		{"1.2.0-nightly", "nightly"},
+		{"1.2.0-SNAPSHOT", "snapshot"},
+		{"1.2.0-insiders", ""},
+	}
+
+	for _, f := range fixtures {
+		tag, err := Parse(f.Tag)
+		if err != nil {
+			t.Fatalf("%q: unexpected error: %s", f.Tag, err)
+		}
+		if actual := p.Channel(tag); actual != f.Expected {
+			t.Fatalf("%q: expected: %q, actual: %q", f.Tag, f.Expected, actual)
+		}
+	}
+}
+
+func TestPrereleasePolicy(t *testing.T) {
+
+	rcOnly, _ := NewPrereleasePolicy([]string{"rc"}, nil)
+	noNightly, _ := NewPrereleasePolicy(nil, []string{"nightly|dev", "insiders"})
+	allowRC, _ := NewPrereleasePolicy([]string{"rc"}, []string{"alpha", "nightly", "dev", "snapshot"})
+
+	fixtures := [...]struct {
+		Policy   *PrereleasePolicy
+		Base     string
+		Tag      string
+		Expected bool
+	}{
+		{DefaultPrereleasePolicy(), "1.2.0", "1.3.0", true},
+		{DefaultPrereleasePolicy(), "1.2.0", "1.3.0-alpine", true},
+		{DefaultPrereleasePolicy(), "1.2.0", "1.3.0-alpha.1", false},
+		{DefaultPrereleasePolicy(), "1.2.0", "1.3.0-beta.1", false},
+		{DefaultPrereleasePolicy(), "1.2.0", "1.3.0-rc.1", false},
+		{DefaultPrereleasePolicy(), "1.2.0-rc.1", "1.2.0-rc.2", true},
+		{DefaultPrereleasePolicy(), "1.2.0-rc.1", "1.2.0-beta.2", false},
+		{rcOnly, "1.2.0", "1.3.0-rc.1", true},
+		{rcOnly, "1.2.0", "1.3.0-beta.1", false},
+		{rcOnly, "1.2.0", "1.3.0-snapshot", false},
+		{noNightly, "1.2.0", "1.3.0-nightly", false},
+		{noNightly, "1.2.0", "1.3.0-dev", false},
+		{noNightly, "1.2.0", "1.3.0-insiders", false},
+		{noNightly, "1.2.0", "1.3.0-beta.1", true},
+		{noNightly, "1.2.0-nightly", "1.3.0-nightly", true},
+		{allowRC, "1.2.0", "1.3.0-rc.1", true},
+		{allowRC, "1.2.0", "1.3.0-alpha.1", false},
+		{allowRC, "1.2.0", "1.3.0-preview", true},
+		{allowRC, "1.2.0-snapshot", "1.3.0-snapshot", true},
+	}
+
+	for _, f := range fixtures {
+		base, _ := Parse(f.Base)
+		tag, _ := Parse(f.Tag)
+		if actual := f.Policy.Filter(base)(tag); actual != f.Expected {
+			t.Fatalf("%q => %q: expected: %t, actual: %t", f.Base, f.Tag, f.Expected, actual)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package tag
+
+import (
+	"slices"
+)
+
+// ReferrerFunc returns the artifact types of the OCI 1.1 referrers (SBOMs,
+// attestations, signatures) of the image t points to; nil if unknown
+type ReferrerFunc func(t *Tag) []string
+
+// ArtifactTypes maps short names of well known artifact types to them
+var ArtifactTypes = map[string]string{
+	"spdx":             "application/spdx+json",
+	"cyclonedx":        "application/vnd.cyclonedx+json",
+	"in-toto":          "application/vnd.in-toto+json",
+	"sigstore-bundle":  "application/vnd.dev.sigstore.bundle.v0.3+json",
+	"cosign-signature": "application/vnd.dev.cosign.artifact.sig.v1+json",
+}
+
+// ArtifactType returns the artifact type named name: "spdx" =>
+// "application/spdx+json"; other names are returned as they are
+func ArtifactType(name string) string {
+	if t, ok := ArtifactTypes[name]; ok {
+		return t
+	}
+	return name
+}
+
+// ArtifactName returns the short name of the artifact type t, if known:
+// "application/spdx+json" => "spdx"
+func ArtifactName(t string) string {
+	for name, at := range ArtifactTypes {
+		if at == t {
+			return name
+		}
+	}
+	return t
+}
+
+// MissingArtifacts returns the required artifact types (or their short
+// names) which are not in have
+func MissingArtifacts(required, have []string) []string {
+
+	missing := []string{}
+	for _, r := range required {
+		if !slices.Contains(have, ArtifactType(r)) {
+			missing = append(missing, r)
+		}
+	}
+	return missing
+}
+
+// RequireArtifacts looks up the referrers of the tags of the list (sorted,
+// newest first) ahead of base and keeps their artifact types as Referrers.
+// Tags lacking one of the required artifact types are dropped and the
+// lookup goes on with the next tag. Only the first limit tags kept are
+// looked up (all tags ahead of base, if limit is 0), but at most MaxLookups
+// tags. Tags whose referrers are unknown lack all required artifact types.
+func (tags List) RequireArtifacts(base *Tag, required []string, referrers ReferrerFunc, limit int) List {
+
+	return tags.require(base, limit, func(t *Tag, known bool) (bool, bool) {
+		t.Referrers = nil
+		if known {
+			t.Referrers = referrers(t)
+		}
+		return len(MissingArtifacts(required, t.Referrers)) == 0, false
+	})
+}
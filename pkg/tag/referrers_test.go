@@ -0,0 +1,60 @@
+package tag
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRequireArtifacts(t *testing.T) {
+
+	in := []string{"3.18.6", "3.19.0", "3.19.1", "3.20.0"}
+	referrers := map[string][]string{
+		"3.20.0": {"application/vnd.dev.cosign.artifact.sig.v1+json"},
+		"3.19.1": {"application/spdx+json", "application/vnd.in-toto+json"},
+		"3.19.0": {"application/spdx+json"},
+	}
+
+	fixtures := [...]struct {
+		Base           string
+		Required       []string
+		Limit          int
+		Expected       []string
+		ExpectedLookup int
+	}{
+		{"3.18.0", nil, 1, []string{"3.20.0 cosign-signature", "3.19.1", "3.19.0", "3.18.6"}, 1},
+		{"3.18.0", []string{"spdx"}, 1, []string{"3.19.1 spdx,in-toto", "3.19.0", "3.18.6"}, 2},
+		{"3.18.0", []string{"spdx", "application/vnd.in-toto+json"}, 0, []string{"3.19.1 spdx,in-toto"}, 4},
+		{"3.18.0", []string{"cyclonedx"}, 1, []string{}, 4},
+		{"3.19.1", []string{"cyclonedx"}, 1, []string{"3.19.1", "3.19.0", "3.18.6"}, 1},
+	}
+
+	for _, f := range fixtures {
+
+		lookups := 0
+		referrersOf := func(t *Tag) []string {
+			lookups++
+			return referrers[t.String()]
+		}
+
+		tags := NewFromStrings(in, SemVerScheme, nil, VariantFilter(Variant{}))
+		tags.Sort()
+		tags.Reverse()
+
+		base, _ := SemVerScheme.Parse(f.Base)
+		actual := []string{}
+		for _, t := range tags.RequireArtifacts(base, f.Required, referrersOf, f.Limit) {
+			names := []string{}
+			for _, r := range t.Referrers {
+				names = append(names, ArtifactName(r))
+			}
+			actual = append(actual, strings.TrimSpace(t.String()+" "+strings.Join(names, ",")))
+		}
+
+		if strings.Join(actual, "|") != strings.Join(f.Expected, "|") {
+			t.Fatalf("%s, %q: expected: %q, actual: %q", f.Base, f.Required, f.Expected, actual)
+		}
+		if lookups != f.ExpectedLookup {
+			t.Fatalf("%s, %q: expected: %d, actual: %d", f.Base, f.Required, f.ExpectedLookup, lookups)
+		}
+	}
+}
@@ -0,0 +1,181 @@
+package tag
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Orderings of the groups of a RegexScheme
+const (
+	OrderNumeric  = "numeric"
+	OrderLexical  = "lexical"
+	OrderCodename = "codename"
+)
+
+// RegexGroup names a capture group of a RegexScheme and how its values
+// are ordered: OrderNumeric (the default), OrderLexical or OrderCodename
+type RegexGroup struct {
+	Name  string `json:"group"`
+	Order string `json:"order,omitempty"`
+}
+
+// RegexScheme is a Scheme defined by a regular expression with named
+// capture groups, eg
+//
+//	^build-(?P<build>\d+)$
+//	^v(?P<major>\d+)\.(?P<minor>\d+)\.(?P<patch>\d+)-eksbuild\.(?P<build>\d+)$
+//
+// The groups are compared in the order given, the first group is the most
+// significant one. A group named "variant" is not compared but becomes
+// the Variant of the tag.
+type RegexScheme struct {
+	name   string
+	re     *regexp.Regexp
+	groups []RegexGroup
+	index  []int
+}
+
+// NewRegexScheme returns a RegexScheme. If groups is empty, all named
+// groups of pattern are compared numerically, in the order of appearance.
+func NewRegexScheme(name, pattern string, groups []RegexGroup) (*RegexScheme, error) {
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(groups) == 0 {
+		for _, n := range re.SubexpNames() {
+			if n != "" && n != groupVariant {
+				groups = append(groups, RegexGroup{Name: n})
+			}
+		}
+	}
+	if len(groups) == 0 {
+		return nil, fmt.Errorf("scheme %q: pattern %q has no named groups", name, pattern)
+	}
+
+	s := &RegexScheme{name: name, re: re}
+	for _, g := range groups {
+		i := re.SubexpIndex(g.Name)
+		if i < 0 {
+			return nil, fmt.Errorf("scheme %q: unknown group %q", name, g.Name)
+		}
+		switch g.Order {
+		case "":
+			g.Order = OrderNumeric
+		case OrderNumeric, OrderLexical, OrderCodename:
+		default:
+			return nil, fmt.Errorf("scheme %q: unknown order %q for group %q", name, g.Order, g.Name)
+		}
+		s.groups = append(s.groups, g)
+		s.index = append(s.index, i)
+	}
+
+	return s, nil
+}
+
+const groupVariant = "variant"
+
+// Name returns the name of the scheme
+func (s *RegexScheme) Name() string { return s.name }
+
+// Parse parses s into a Tag if tag matches the pattern of s
+func (s *RegexScheme) Parse(tag string) (*Tag, error) {
+
+	m := s.re.FindStringSubmatch(tag)
+	if m == nil {
+		return nil, fmt.Errorf("tag %q does not match scheme %q", tag, s.name)
+	}
+
+	v := RegexVersion{scheme: s, Fields: make([]string, len(s.index))}
+	for i, idx := range s.index {
+		v.Fields[i] = m[idx]
+		if s.groups[i].Order == OrderNumeric {
+			if _, err := strconv.ParseUint(m[idx], 10, 64); err != nil {
+				return nil, fmt.Errorf("scheme %q: group %q: %w", s.name, s.groups[i].Name, err)
+			}
+		}
+	}
+
+	t := &Tag{Scheme: s, Version: v, original: tag}
+	if i := s.re.SubexpIndex(groupVariant); i >= 0 {
+		t.Variant = ParseVariant(m[i])
+	}
+	return t, nil
+}
+
+// Keep only keeps tags sharing the first groups with base: one for
+// KeepMajor, two for KeepMinor, three for KeepPatch
+func (s *RegexScheme) Keep(base *Tag, keepLevel int) FilterFunc {
+
+	bv, _ := base.Version.(RegexVersion)
+	n := keptComponents(keepLevel)
+	return func(t *Tag) bool {
+		v, ok := t.Version.(RegexVersion)
+		return ok && bv.sharesPrefix(v, n)
+	}
+}
+
+// RegexVersion is the version of a tag parsed by a RegexScheme: the values
+// of the ordered groups
+type RegexVersion struct {
+	Fields []string
+	scheme *RegexScheme
+}
+
+// Compare compares v and o group by group, according to the ordering of
+// each group
+func (v RegexVersion) Compare(o Version) int {
+
+	ov, ok := o.(RegexVersion)
+	if !ok || ov.scheme != v.scheme {
+		return 0
+	}
+
+	for i, g := range v.scheme.groups {
+		a, b := v.Fields[i], ov.Fields[i]
+		c := 0
+		switch g.Order {
+		case OrderNumeric:
+			na, _ := strconv.ParseUint(a, 10, 64)
+			nb, _ := strconv.ParseUint(b, 10, 64)
+			c = compareUint(na, nb)
+		case OrderLexical:
+			c = strings.Compare(a, b)
+		case OrderCodename:
+			c = compareCodenames(a, b)
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+// Contains reports if o carries the same values as v
+func (v RegexVersion) Contains(o Version) bool {
+
+	ov, ok := o.(RegexVersion)
+	return ok && v.sharesPrefix(ov, len(v.Fields))
+}
+
+// String satisfies the Stringer interface
+func (v RegexVersion) String() string {
+	return strings.Join(v.Fields, ".")
+}
+
+func (v RegexVersion) sharesPrefix(o RegexVersion, n int) bool {
+
+	if v.scheme != o.scheme {
+		return false
+	}
+	for i := 0; i < n && i < len(v.Fields); i++ {
+		if v.Fields[i] != o.Fields[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,76 @@
+package tag
+
+import (
+	"testing"
+)
+
+func TestRegexScheme(t *testing.T) {
+
+	eks, err := NewRegexScheme("eksbuild", `^v(?P<major>\d+)\.(?P<minor>\d+)\.(?P<patch>\d+)-eksbuild\.(?P<build>\d+)$`, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	in := []string{"v1.2.3-eksbuild.10", "v1.2.3-eksbuild.2", "v1.10.0-eksbuild.1", "v1.2.3", "latest"}
+	expected := []string{"v1.2.3-eksbuild.2", "v1.2.3-eksbuild.10", "v1.10.0-eksbuild.1"}
+
+	tags := NewFromStrings(in, eks, nil, ApplyFilterList(nil))
+	tags.Sort()
+
+	if len(expected) != len(tags) {
+		t.Fatalf("expected: %q, actual: %q", expected, tags)
+	}
+	for i := range expected {
+		if expected[i] != tags[i].String() {
+			t.Fatalf("expected: %q, actual: %q", expected[i], tags[i].String())
+		}
+	}
+
+	base, _ := eks.Parse("v1.2.3-eksbuild.2")
+	kept := NewFromStrings(in, eks, nil, eks.Keep(base, KeepMinor))
+	if len(kept) != 2 {
+		t.Fatalf("expected: 2, actual: %d", len(kept))
+	}
+}
+
+func TestRegexSchemeOrder(t *testing.T) {
+
+	s, err := NewRegexScheme("release", `^(?P<distro>[a-z]+)-r(?P<rev>\d+)$`, []RegexGroup{
+		{Name: "distro", Order: OrderCodename},
+		{Name: "rev"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	in := []string{"bookworm-r1", "bullseye-r9", "bookworm-r10", "bookworm-r2"}
+	expected := []string{"bullseye-r9", "bookworm-r1", "bookworm-r2", "bookworm-r10"}
+
+	tags := NewFromStrings(in, s, nil, ApplyFilterList(nil))
+	tags.Sort()
+
+	for i := range expected {
+		if expected[i] != tags[i].String() {
+			t.Fatalf("expected: %q, actual: %q", expected[i], tags[i].String())
+		}
+	}
+}
+
+func TestRegexSchemeErrors(t *testing.T) {
+
+	fixtures := [...]struct {
+		Pattern string
+		Groups  []RegexGroup
+	}{
+		{`^build-(\d+)$`, nil},
+		{`^build-(?P<build>\d+$`, nil},
+		{`^build-(?P<build>\d+)$`, []RegexGroup{{Name: "unknown"}}},
+		{`^build-(?P<build>\d+)$`, []RegexGroup{{Name: "build", Order: "random"}}},
+	}
+
+	for _, f := range fixtures {
+		if _, err := NewRegexScheme("test", f.Pattern, f.Groups); err == nil {
+			t.Fatalf("%q: expected error", f.Pattern)
+		}
+	}
+}
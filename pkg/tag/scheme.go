@@ -0,0 +1,60 @@
+package tag
+
+import "errors"
+
+// Scheme describes how the tags of a repository are versioned
+type Scheme interface {
+	// Name returns the name of the scheme, eg "semver"
+	Name() string
+
+	// Parse parses s into a Tag. Tags which do not follow the scheme yield
+	// an error.
+	Parse(s string) (*Tag, error)
+
+	// Keep returns a FilterFunc which only keeps tags sharing the version
+	// of base up to keepLevel (KeepMajor, KeepMinor, KeepPatch)
+	Keep(base *Tag, keepLevel int) FilterFunc
+}
+
+// Known schemes
+var (
+	SemVerScheme   Scheme = semverScheme{}
+	CalVerScheme   Scheme = calverScheme{}
+	NumericScheme  Scheme = numericScheme{}
+	CodenameScheme Scheme = codenameScheme{}
+)
+
+// schemes lists the schemes in the order they are tried by Parse. CalVer
+// comes first as most of the date based tags ("20240427", "2024.05.01")
+// would be accepted by semver as well. Numeric only takes what semver
+// refuses, eg "4.8.1.2".
+var schemes = []Scheme{CalVerScheme, SemVerScheme, NumericScheme, CodenameScheme}
+
+// LookupScheme returns the built-in scheme called name
+func LookupScheme(name string) (Scheme, bool) {
+
+	for _, scheme := range schemes {
+		if scheme.Name() == name {
+			return scheme, true
+		}
+	}
+	return nil, false
+}
+
+// ErrUnknownScheme is returned by Parse for tags which do not follow any of
+// the known schemes
+var ErrUnknownScheme = errors.New("unknown version scheme")
+
+// Parse parses s into a Tag, using the first scheme which accepts s. The
+// Scheme of the resulting Tag is then used to parse the other tags of the
+// repository, see NewFromStrings. Schemes which can not be detected (like a
+// RegexScheme) have to be used directly.
+func Parse(s string) (*Tag, error) {
+
+	for _, scheme := range schemes {
+		if t, err := scheme.Parse(s); err == nil {
+			return t, nil
+		}
+	}
+	return nil, ErrUnknownScheme
+}
@@ -0,0 +1,23 @@
+package tag
+
+// SignatureFunc returns the state of the signatures of the image t points
+// to ("signed", "unsigned", …; "" if unknown) and whether it is signed as
+// required
+type SignatureFunc func(t *Tag) (status string, signed bool)
+
+// RequireSignatures looks up the signatures of the tags of the list
+// (sorted, newest first) ahead of base and keeps their state as Signature.
+// If skip is set, tags which are not signed are dropped and the lookup goes
+// on with the next tag. Only the first limit tags kept are looked up (all
+// tags ahead of base, if limit is 0), but at most MaxLookups tags.
+func (tags List) RequireSignatures(base *Tag, status SignatureFunc, limit int, skip bool) List {
+
+	return tags.require(base, limit, func(t *Tag, known bool) (bool, bool) {
+		t.Signature = ""
+		signed := false
+		if known {
+			t.Signature, signed = status(t)
+		}
+		return signed || !skip, false
+	})
+}
@@ -0,0 +1,57 @@
+package tag
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRequireSignatures(t *testing.T) {
+
+	in := []string{"3.18.6", "3.19.0", "3.19.1", "3.20.0"}
+	states := map[string]string{
+		"3.20.0": "unsigned",
+		"3.19.1": "other-key",
+		"3.19.0": "signed",
+		"3.18.6": "signed",
+	}
+
+	fixtures := [...]struct {
+		Base           string
+		Limit          int
+		Skip           bool
+		Expected       []string
+		ExpectedLookup int
+	}{
+		{"3.18.0", 1, false, []string{"3.20.0 unsigned", "3.19.1", "3.19.0", "3.18.6"}, 1},
+		{"3.18.0", 1, true, []string{"3.19.0 signed", "3.18.6"}, 3},
+		{"3.18.0", 0, true, []string{"3.19.0 signed", "3.18.6 signed"}, 4},
+		{"3.19.0", 1, true, []string{"3.19.0", "3.18.6"}, 2},
+	}
+
+	for _, f := range fixtures {
+
+		lookups := 0
+		status := func(t *Tag) (string, bool) {
+			lookups++
+			s := states[t.String()]
+			return s, s == "signed"
+		}
+
+		tags := NewFromStrings(in, SemVerScheme, nil, VariantFilter(Variant{}))
+		tags.Sort()
+		tags.Reverse()
+
+		base, _ := SemVerScheme.Parse(f.Base)
+		actual := []string{}
+		for _, t := range tags.RequireSignatures(base, status, f.Limit, f.Skip) {
+			actual = append(actual, strings.TrimSpace(t.String()+" "+t.Signature))
+		}
+
+		if strings.Join(actual, "|") != strings.Join(f.Expected, "|") {
+			t.Fatalf("base %s, limit %d, skip %t: expected: %q, actual: %q", f.Base, f.Limit, f.Skip, f.Expected, actual)
+		}
+		if lookups != f.ExpectedLookup {
+			t.Fatalf("base %s, limit %d, skip %t: expected: %d, actual: %d", f.Base, f.Limit, f.Skip, f.ExpectedLookup, lookups)
+		}
+	}
+}
@@ -0,0 +1,24 @@
+package tag
+
+// SizeFunc returns the compressed size of the image t points to in bytes;
+// 0 if unknown
+type SizeFunc func(t *Tag) int64
+
+// LookupSizes looks up the Size of the tags of the list (sorted, newest
+// first). Only the first limit tags are looked up (all, if limit is 0);
+// tags missing a required platform are looked up, but do not count.
+func (tags List) LookupSizes(size SizeFunc, limit int) {
+
+	n := 0
+	for _, t := range tags {
+
+		if limit > 0 && n >= limit {
+			return
+		}
+
+		t.Size = size(t)
+		if len(t.MissingPlatforms) == 0 {
+			n++
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package tag
+
+import (
+	"testing"
+)
+
+func TestLookupSizes(t *testing.T) {
+
+	in := []string{"3.18.6", "3.19.0", "3.19.1", "3.20.0"}
+	sizes := map[string]int64{
+		"3.20.0": 4000,
+		"3.19.1": 3500,
+		"3.19.0": 3400,
+		"3.18.6": 3300,
+	}
+
+	fixtures := [...]struct {
+		Limit    int
+		Missing  string // tag missing a required platform
+		Expected []int64
+	}{
+		{1, "", []int64{4000, 0, 0, 0}},
+		{1, "3.20.0", []int64{4000, 3500, 0, 0}},
+		{0, "", []int64{4000, 3500, 3400, 3300}},
+	}
+
+	for _, f := range fixtures {
+
+		tags := NewFromStrings(in, SemVerScheme, nil, VariantFilter(Variant{}))
+		tags.Sort()
+		tags.Reverse()
+		for _, t := range tags {
+			if t.String() == f.Missing {
+				t.MissingPlatforms = []string{"linux/arm64"}
+			}
+		}
+
+		tags.LookupSizes(func(t *Tag) int64 { return sizes[t.String()] }, f.Limit)
+
+		for i, tag := range tags {
+			if tag.Size != f.Expected[i] {
+				t.Fatalf("limit %d, %s: expected: %d, actual: %d", f.Limit, tag, f.Expected[i], tag.Size)
+			}
+		}
+	}
+}
@@ -1,18 +1,170 @@
 package tag
 
 import (
+	"cmp"
+	"regexp"
 	"slices"
-	"sort"
+	"strconv"
+	"strings"
 
 	semver "github.com/Masterminds/semver/v3"
 )
 
-// List contains semantic versions
-type List []*semver.Version
+// Tag is a single tag of a container image: the version, according to the
+// Scheme of the tag, plus the variant which is encoded in the remaining
+// parts of the tag ("1.25.3-alpine3.18", "jammy-20240427").
+//
+// Tags like "1.25.3-1", "3.18.4-r2" or "2.3.0_7" denote rebuilds of the
+// same upstream version, the number is kept as Revision (0 if not given).
+//
+// Aliases holds the names of other tags pointing to the same image, see
+// List.Collapse.
+//
+// Platforms holds the platforms of the image the tag points to,
+// MissingPlatforms the required ones it lacks, see List.RequirePlatforms.
+// Signature holds the state of its signatures, see List.RequireSignatures,
+// Referrers the artifact types attached to it, see List.RequireArtifacts.
+// Size is the compressed size of its image in bytes, see List.LookupSizes.
+type Tag struct {
+	Scheme   Scheme
+	Version  Version
+	Revision int
+	Variant  Variant
+	Aliases  []string
 
-// Sort sorts the list of tags according to semantic versions
+	Platforms        []string
+	MissingPlatforms []string
+	Signature        string
+	Referrers        []string
+	Size             int64
+
+	original string
+}
+
+// List contains tags
+type List []*Tag
+
+// New returns a semver Tag for v. The variant is derived from the
+// pre-release part of v.
+func New(v *semver.Version) *Tag {
+	return newTag(SemVerScheme, SemVer{v}, v.Prerelease(), v.Original())
+}
+
+// newTag returns a Tag where the revision and the variant are taken from
+// suffix
+func newTag(scheme Scheme, v Version, suffix, original string) *Tag {
+	rev, rest := splitRevision(suffix)
+	return &Tag{
+		Scheme:   scheme,
+		Version:  v,
+		Revision: rev,
+		Variant:  ParseVariant(rest),
+		original: original,
+	}
+}
+
+// reRevision matches a part of a suffix denoting a rebuild: "1", "r2"
+var reRevision = regexp.MustCompile(`^r?(\d+)$`)
+
+// splitRevision splits the revision off the suffix s. The revision is
+// either the first or the last part of s: "r2" => 2, "1-alpine" => 1 +
+// "alpine", "alpine-r3" => 3 + "alpine"
+func splitRevision(s string) (int, string) {
+
+	if s == "" {
+		return 0, s
+	}
+
+	parts := strings.Split(s, sepVariantParts)
+	for _, i := range []int{0, len(parts) - 1} {
+		m := reRevision.FindStringSubmatch(parts[i])
+		if m == nil {
+			continue
+		}
+		rev, err := strconv.Atoi(m[1])
+		if err != nil {
+			continue
+		}
+		return rev, strings.Join(slices.Delete(parts, i, i+1), sepVariantParts)
+	}
+	return 0, s
+}
+
+// ParseWithLabel parses the tag of a requested image where the label was
+// already split off (see imagespec.SplitTagLabel). If scheme is nil, the
+// scheme is detected, see Parse.
+func ParseWithLabel(scheme Scheme, tag, label string) (*Tag, error) {
+
+	parse := Parse
+	if scheme != nil {
+		parse = scheme.Parse
+	}
+
+	if label != "" {
+		if t, err := parse(tag + sepVariantParts + label); err == nil {
+			return t, nil
+		}
+	}
+
+	t, err := parse(tag)
+	if err != nil || label == "" {
+		return t, err
+	}
+	rev, rest := splitRevision(label)
+	t.Revision, t.Variant = rev, ParseVariant(rest)
+	t.original = tag + sepVariantParts + label
+	return t, nil
+}
+
+// SemVer returns the semantic version of t; nil if t follows another scheme
+func (t *Tag) SemVer() *semver.Version {
+	if v, ok := t.Version.(SemVer); ok {
+		return v.Version
+	}
+	return nil
+}
+
+// Constrainable reports whether the version of t can be checked against
+// semver.Constraints: semantic and numeric versions can, calendar versions,
+// codenames and regex versions can not
+func (t *Tag) Constrainable() bool {
+	return asSemVer(t.Version) != nil
+}
+
+// String satisfies the Stringer interface. It returns the tag as published
+// ("v2.4.7", "3.13"), the normalized version is available via Version.
+func (t *Tag) String() string {
+	if t.original == "" && t.Version != nil {
+		return t.Version.String()
+	}
+	return t.original
+}
+
+// Compare compares the Tag t to o. It returns -1, 0, or 1 if t is smaller,
+// equal, or larger than o. The version is compared first, then the
+// revision. For equal versions and revisions, variants of the same name are
+// compared by their version, everything else falls back to semver's
+// pre-release ordering.
+func (t *Tag) Compare(o *Tag) int {
+
+	if c := t.Version.Compare(o.Version); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(t.Revision, o.Revision); c != 0 {
+		return c
+	}
+	if c := t.Variant.Compare(o.Variant); c != 0 {
+		return c
+	}
+	if a, b := t.SemVer(), o.SemVer(); a != nil && b != nil {
+		return a.Compare(b)
+	}
+	return 0
+}
+
+// Sort sorts the list of tags according to their versions
 func (tags List) Sort() {
-	sort.Sort(semver.Collection(tags))
+	slices.SortStableFunc(tags, func(a, b *Tag) int { return a.Compare(b) })
 }
 
 // Reverse reverses the order of the TagList tags
@@ -20,23 +172,28 @@ func (tags List) Reverse() {
 	slices.Reverse(tags)
 }
 
-// NewFromStrings creates a new List, based upon the string list "tags". In
-// addition, it applies the filter function extraFilter
-func NewFromStrings(tags []string, extraFilter FilterFunc) List {
+// NewFromStrings creates a new List, based upon the string list "tags" which
+// are parsed according to scheme. The names are filtered by nameFilter (if
+// given) before parsing, the parsed tags by the filter function extraFilter
+func NewFromStrings(tags []string, scheme Scheme, nameFilter NameFilterFunc, extraFilter FilterFunc) List {
 
 	filtered := List{}
 
 	for i := range tags {
-		tv, err := semver.NewVersion(tags[i])
+		if nameFilter != nil && !nameFilter(tags[i]) {
+			continue
+		}
+
+		t, err := scheme.Parse(tags[i])
 		if err != nil {
 			continue
 		}
 
-		if !extraFilter(tv) {
+		if !extraFilter(t) {
 			continue
 		}
 
-		filtered = append(filtered, tv)
+		filtered = append(filtered, t)
 	}
 
 	return filtered
@@ -1 +1,136 @@
 package tag
+
+import (
+	"testing"
+)
+
+func TestSort(t *testing.T) {
+
+	in := []string{"1.25.3-alpine3.9", "1.25.3-alpine3.19", "1.24.0-alpine3.19", "1.25.3-alpine3.18", "1.26.0-alpine3.9"}
+	expected := []string{"1.24.0-alpine3.19", "1.25.3-alpine3.9", "1.25.3-alpine3.18", "1.25.3-alpine3.19", "1.26.0-alpine3.9"}
+
+	tags := NewFromStrings(in, SemVerScheme, nil, ApplyFilterList(nil))
+	tags.Sort()
+
+	for i := range expected {
+		if expected[i] != tags[i].String() {
+			t.Fatalf("expected: %q, actual: %q", expected[i], tags[i].String())
+		}
+	}
+}
+
+func TestOriginalSpelling(t *testing.T) {
+
+	fixtures := [...]struct {
+		Tag             string
+		ExpectedVersion string
+	}{
+		{"v2.4.7", "2.4.7"},
+		{"3.13", "3.13.0"},
+		{"v1.25-alpine", "1.25.0-alpine"},
+		{"2.3.0_7", "2.3.0"},
+		{"jammy-20240427", "2024.04.27"},
+	}
+
+	for _, f := range fixtures {
+		tag, err := Parse(f.Tag)
+		if err != nil {
+			t.Fatalf("%q: unexpected error: %s", f.Tag, err)
+		}
+		if tag.String() != f.Tag {
+			t.Fatalf("expected: %q, actual: %q", f.Tag, tag.String())
+		}
+		if tag.Version.String() != f.ExpectedVersion {
+			t.Fatalf("%q: expected: %q, actual: %q", f.Tag, f.ExpectedVersion, tag.Version.String())
+		}
+	}
+}
+
+func TestRevision(t *testing.T) {
+
+	fixtures := [...]struct {
+		Tag              string
+		ExpectedRevision int
+		ExpectedVariant  string
+	}{
+		{"1.25.3", 0, ""},
+		{"1.25.3-1", 1, ""},
+		{"3.18.4-r2", 2, ""},
+		{"2.3.0_7", 7, ""},
+		{"2.3.0_7-alpine", 7, "alpine"},
+		{"1.25.3-2-alpine", 2, "alpine"},
+		{"1.25.3-alpine-r3", 3, "alpine"},
+		{"1.25.3-alpine3.18", 0, "alpine"},
+	}
+
+	for _, f := range fixtures {
+		tag, err := Parse(f.Tag)
+		if err != nil {
+			t.Fatalf("%q: unexpected error: %s", f.Tag, err)
+		}
+		if f.ExpectedRevision != tag.Revision {
+			t.Fatalf("%q: expected: %d, actual: %d", f.Tag, f.ExpectedRevision, tag.Revision)
+		}
+		if f.ExpectedVariant != tag.Variant.Name {
+			t.Fatalf("%q: expected: %q, actual: %q", f.Tag, f.ExpectedVariant, tag.Variant.Name)
+		}
+	}
+}
+
+func TestCompareRevision(t *testing.T) {
+
+	fixtures := [...]struct {
+		Base            string
+		Other           string
+		ExpectedVerdict Verdict
+		ExpectedKind    UpdateKind
+	}{
+		{"1.25.3", "1.25.3-1", VerdictAhead, UpdateRebuild},
+		{"3.18.4-r1", "3.18.4-r2", VerdictAhead, UpdateRebuild},
+		{"3.18.4-r2", "3.18.4-r1", VerdictOutdated, UpdateNone},
+		{"3.18.4-r2", "3.18.4-r2", VerdictEqual, UpdateNone},
+		{"3.18.4-r2", "3.18.5", VerdictAhead, UpdatePatch},
+		{"3.18.4", "3.19.0", VerdictAhead, UpdateMinor},
+		{"3.18.4", "4.0.0", VerdictAhead, UpdateMajor},
+		{"3.18.4", "3.19.0-rc.1", VerdictAhead, UpdatePrerelease},
+		{"3.19.0-rc.1", "3.19.0-rc.2", VerdictAhead, UpdatePrerelease},
+		{"3.19.0-rc.2", "3.19.0-beta.3", VerdictOutdated, UpdateNone},
+		{"3.19.0-rc.2", "3.19.0", VerdictAhead, UpdateMinor},
+		{"3.19.1-rc.1", "3.19.1", VerdictAhead, UpdatePatch},
+		{"4.0.0-rc.1", "4.0.0", VerdictAhead, UpdateMajor},
+		{"3.19.0-alpine", "3.19.0-rc.1-alpine", VerdictOutdated, UpdateNone},
+		{"2.3.0_7", "2.3.0_8", VerdictAhead, UpdateRebuild},
+		{"3.18", "3.18.4-r2", VerdictEqual, UpdateNone},
+	}
+
+	for _, f := range fixtures {
+		base, _ := Parse(f.Base)
+		other, _ := Parse(f.Other)
+
+		verdict, kind := Compare(base, other)
+
+		t.Logf("%q vs %q => %q %q", f.Base, f.Other, verdict, kind)
+
+		if f.ExpectedVerdict != verdict {
+			t.Fatalf("expected: %q, actual: %q", f.ExpectedVerdict, verdict)
+		}
+		if f.ExpectedKind != kind {
+			t.Fatalf("expected: %q, actual: %q", f.ExpectedKind, kind)
+		}
+	}
+}
+
+func TestSortRevision(t *testing.T) {
+
+	in := []string{"1.25.3-2", "1.25.4", "1.25.3", "1.25.3-10", "1.25.3-1"}
+	expected := []string{"1.25.3", "1.25.3-1", "1.25.3-2", "1.25.3-10", "1.25.4"}
+
+	tags := NewFromStrings(in, SemVerScheme, nil, ApplyFilterList(nil))
+	tags.Sort()
+
+	for i := range expected {
+		if expected[i] != tags[i].String() {
+			t.Fatalf("expected: %q, actual: %q", expected[i], tags[i].String())
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package tag
+
+// Tiers holds the newest update of base per tier: Patch keeps the minor
+// version of base ("1.21.3" => "1.21.13"), Minor keeps the major version
+// ("1.21.3" => "1.23.4") and Major holds the newest tag beyond that
+// ("1.21.3" => "2.0.1"). A tier without any update is nil.
+type Tiers struct {
+	Patch *Tag
+	Minor *Tag
+	Major *Tag
+}
+
+// Tiers sorts the tags of the list ahead of base into Tiers. The tiers are
+// derived from the Keep filters of the scheme of base.
+func (tags List) Tiers(base *Tag) Tiers {
+
+	tiers := Tiers{}
+	tier := tierFunc(base)
+
+	for _, t := range tags {
+		if verdict, _ := Compare(base, t); verdict != VerdictAhead {
+			continue
+		}
+		switch tier(t) {
+		case 0:
+			tiers.Patch = newest(tiers.Patch, t)
+		case 1:
+			tiers.Minor = newest(tiers.Minor, t)
+		default:
+			tiers.Major = newest(tiers.Major, t)
+		}
+	}
+
+	return tiers
+}
+
+// TierCandidates splits the tags of the list ahead of base by their tier:
+// patch, minor and major candidates, in the order of the list. The costly
+// lookups deciding about a candidate (platforms, signatures, …) are meant
+// to run per tier, to find the newest suitable tag of each tier.
+func (tags List) TierCandidates(base *Tag) [3]List {
+
+	candidates := [3]List{}
+	tier := tierFunc(base)
+
+	for _, t := range tags {
+		if verdict, _ := Compare(base, t); verdict == VerdictAhead {
+			i := tier(t)
+			candidates[i] = append(candidates[i], t)
+		}
+	}
+	return candidates
+}
+
+// tierFunc returns a func which returns the tier of a tag relative to base:
+// 0 for patch, 1 for minor, 2 for major updates
+func tierFunc(base *Tag) func(t *Tag) int {
+
+	keepMinor := base.Scheme.Keep(base, KeepMinor)
+	keepMajor := base.Scheme.Keep(base, KeepMajor)
+
+	return func(t *Tag) int {
+		switch {
+		case keepMinor(t):
+			return 0
+		case keepMajor(t):
+			return 1
+		}
+		return 2
+	}
+}
+
+// Empty returns true if no tier holds an update
+func (tiers Tiers) Empty() bool {
+	return tiers.Patch == nil && tiers.Minor == nil && tiers.Major == nil
+}
+
+func newest(a, b *Tag) *Tag {
+	if a == nil || b.Compare(a) > 0 {
+		return b
+	}
+	return a
+}
@@ -0,0 +1,66 @@
+package tag
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTiers(t *testing.T) {
+
+	in := []string{"1.20.9", "1.21.2", "1.21.3", "1.21.13", "1.21.8", "1.22.0", "1.23.4", "1.23.1", "2.0.1", "3.0.0-rc.1"}
+	tags := NewFromStrings(in, SemVerScheme, nil, ApplyFilterList(nil))
+
+	str := func(t *Tag) string {
+		if t == nil {
+			return ""
+		}
+		return t.String()
+	}
+
+	fixtures := [...]struct {
+		Base          string
+		ExpectedPatch string
+		ExpectedMinor string
+		ExpectedMajor string
+	}{
+		{"1.21.3", "1.21.13", "1.23.4", "3.0.0-rc.1"},
+		{"1.23.4", "", "", "3.0.0-rc.1"},
+		{"1.21", "", "1.23.4", "3.0.0-rc.1"},
+		{"3.0.0", "", "", ""},
+	}
+
+	for _, f := range fixtures {
+		base, _ := Parse(f.Base)
+		tiers := tags.Tiers(base)
+		if actual := str(tiers.Patch); actual != f.ExpectedPatch {
+			t.Fatalf("%q patch: expected: %q, actual: %q", f.Base, f.ExpectedPatch, actual)
+		}
+		if actual := str(tiers.Minor); actual != f.ExpectedMinor {
+			t.Fatalf("%q minor: expected: %q, actual: %q", f.Base, f.ExpectedMinor, actual)
+		}
+		if actual := str(tiers.Major); actual != f.ExpectedMajor {
+			t.Fatalf("%q major: expected: %q, actual: %q", f.Base, f.ExpectedMajor, actual)
+		}
+	}
+}
+
+func TestTierCandidates(t *testing.T) {
+
+	in := []string{"1.20.9", "1.21.3", "1.21.13", "1.21.8", "1.22.0", "1.23.4", "2.0.1"}
+	tags := NewFromStrings(in, SemVerScheme, nil, ApplyFilterList(nil))
+	tags.Sort()
+	tags.Reverse()
+
+	base, _ := Parse("1.21.3")
+	expected := [3]string{"1.21.13 1.21.8", "1.23.4 1.22.0", "2.0.1"}
+
+	for i, candidates := range tags.TierCandidates(base) {
+		actual := []string{}
+		for _, c := range candidates {
+			actual = append(actual, c.String())
+		}
+		if strings.Join(actual, " ") != expected[i] {
+			t.Fatalf("tier %d: expected: %q, actual: %q", i, expected[i], actual)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package tag
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+// Variant describes the suffix of a tag like "1.25.3-alpine3.18" or
+// "17-jdk-jammy". Many repos encode the flavour of an image (the base OS,
+// the toolchain etc) in that suffix, and sometimes the flavour itself carries
+// a version or the codename of a distribution release:
+//
+//	"alpine3.18"    => Name: "alpine", Version: "3.18"
+//	"slim-bookworm" => Name: "slim", Codename: "bookworm"
+//	"jdk-jammy"     => Name: "jdk", Codename: "jammy"
+//
+// Variants with the same Name can be compared by their Codename and Version.
+type Variant struct {
+	Name     string
+	Version  *semver.Version
+	Codename string
+	Distro   string
+
+	raw string
+}
+
+const (
+	sepVariantParts = "-"
+)
+
+// reVersionedPart matches a part of a variant suffix which ends in a version
+// number, eg "alpine3.18", "ubi9", "ltsc2022" or "eksbuild.1"
+var reVersionedPart = regexp.MustCompile(`^([a-zA-Z][a-zA-Z_]*?)[._]?(\d+(?:\.\d+){0,2})$`)
+
+// ParseVariant parses the suffix s of a tag into a Variant. A part of s which
+// is a known codename (see LookupCodename) becomes the Codename. If several
+// parts of s carry a version, the last one wins, the others are kept as part
+// of the name: "python3.11-alpine3.18" => "python3.11-alpine" + "3.18"
+func ParseVariant(s string) Variant {
+
+	if s == "" {
+		return Variant{}
+	}
+
+	variant := Variant{raw: s}
+	parts := strings.Split(s, sepVariantParts)
+
+	for i, part := range parts {
+		if distro, _, ok := LookupCodename(part); ok {
+			variant.Codename, variant.Distro = part, distro
+			parts = slices.Delete(parts, i, i+1)
+			break
+		}
+	}
+
+	for i := len(parts) - 1; i >= 0; i-- {
+		m := reVersionedPart.FindStringSubmatch(parts[i])
+		if m == nil {
+			continue
+		}
+		v, err := semver.NewVersion(m[2])
+		if err != nil {
+			continue
+		}
+		parts[i] = m[1]
+		variant.Version = v
+		break
+	}
+
+	variant.Name = strings.Join(parts, sepVariantParts)
+
+	return variant
+}
+
+// String returns the suffix the Variant was parsed from
+func (v Variant) String() string {
+	return v.raw
+}
+
+// Compare compares the Variant v to o. It returns -1, 0, or 1 if v is
+// smaller, equal, or larger than o. The codenames are compared first, then
+// the versions. Variants with different names or without codenames and
+// versions are not comparable and yield 0.
+func (v Variant) Compare(o Variant) int {
+
+	if v.Name != o.Name {
+		return 0
+	}
+	if c := compareCodenames(v.Codename, o.Codename); c != 0 {
+		return c
+	}
+	if v.Version == nil || o.Version == nil {
+		return 0
+	}
+	return v.Version.Compare(o.Version)
+}
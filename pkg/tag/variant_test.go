@@ -0,0 +1,90 @@
+package tag
+
+import (
+	"testing"
+)
+
+func TestParseVariant(t *testing.T) {
+
+	fixtures := [...]struct {
+		Suffix           string
+		ExpectedName     string
+		ExpectedVersion  string
+		ExpectedCodename string
+	}{
+		{"", "", "", ""},
+		{"alpine", "alpine", "", ""},
+		{"alpine3.18", "alpine", "3.18.0", ""},
+		{"slim-bookworm", "slim", "", "bookworm"},
+		{"bookworm-slim", "slim", "", "bookworm"},
+		{"jdk-jammy", "jdk", "", "jammy"},
+		{"bullseye", "", "", "bullseye"},
+		{"ubi9", "ubi", "9.0.0", ""},
+		{"eksbuild.1", "eksbuild", "1.0.0", ""},
+		{"windowsservercore-ltsc2022", "windowsservercore-ltsc", "2022.0.0", ""},
+		{"python3.11-alpine3.18", "python3.11-alpine", "3.18.0", ""},
+	}
+
+	for _, f := range fixtures {
+		v := ParseVariant(f.Suffix)
+
+		version := ""
+		if v.Version != nil {
+			version = v.Version.String()
+		}
+
+		t.Logf("%q => %q %q %q", f.Suffix, v.Name, version, v.Codename)
+
+		if f.ExpectedName != v.Name {
+			t.Fatalf("expected: %q, actual: %q", f.ExpectedName, v.Name)
+		}
+		if f.ExpectedVersion != version {
+			t.Fatalf("expected: %q, actual: %q", f.ExpectedVersion, version)
+		}
+		if f.ExpectedCodename != v.Codename {
+			t.Fatalf("expected: %q, actual: %q", f.ExpectedCodename, v.Codename)
+		}
+	}
+}
+
+func TestCompareVariants(t *testing.T) {
+
+	fixtures := [...]struct {
+		Base            string
+		Other           string
+		ExpectedVerdict Verdict
+		ExpectedKind    UpdateKind
+	}{
+		{"1.25.3-alpine3.18", "1.25.3-alpine3.18", VerdictEqual, UpdateNone},
+		{"1.25.3-alpine3.18", "1.25.3-alpine3.19", VerdictAhead, UpdateVariant},
+		{"1.25.3-alpine3.18", "1.25.3-alpine3.9", VerdictOutdated, UpdateNone},
+		{"1.25.3-alpine3.18", "1.26.0-alpine3.18", VerdictAhead, UpdateMinor},
+		{"1.25.3-alpine3.18", "1.26.0-alpine3.19", VerdictAhead, UpdateMinor},
+		{"1.25.3-alpine3.18", "1.24.0-alpine3.19", VerdictOutdated, UpdateNone},
+		{"1.25.3-alpine3.18", "1.25.3-bookworm", VerdictEqual, UpdateNone},
+		{"1.25", "1.25.3", VerdictEqual, UpdateNone},
+		{"3.11-bullseye", "3.11-bookworm", VerdictAhead, UpdateDistro},
+		{"3.11-slim-bookworm", "3.11-slim-bullseye", VerdictOutdated, UpdateNone},
+		{"3.11-slim-bookworm", "3.11-bullseye", VerdictEqual, UpdateNone},
+		{"3.12-bullseye", "3.11-bookworm", VerdictOutdated, UpdateNone},
+		{"17-jdk-focal", "17-jdk-noble", VerdictAhead, UpdateDistro},
+		{"bullseye", "trixie", VerdictAhead, UpdateDistro},
+		{"bookworm-slim", "trixie-slim", VerdictAhead, UpdateDistro},
+	}
+
+	for _, f := range fixtures {
+		base, _ := Parse(f.Base)
+		other, _ := Parse(f.Other)
+
+		verdict, kind := Compare(base, other)
+
+		t.Logf("%q vs %q => %q %q", f.Base, f.Other, verdict, kind)
+
+		if f.ExpectedVerdict != verdict {
+			t.Fatalf("expected: %q, actual: %q", f.ExpectedVerdict, verdict)
+		}
+		if f.ExpectedKind != kind {
+			t.Fatalf("expected: %q, actual: %q", f.ExpectedKind, kind)
+		}
+	}
+}
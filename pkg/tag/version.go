@@ -0,0 +1,136 @@
+package tag
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+// Version is the version part of a Tag, as understood by its Scheme
+type Version interface {
+	// Compare returns -1, 0, or 1 if the version is smaller, equal, or
+	// larger than o. Versions of different schemes are not comparable and
+	// yield 0.
+	Compare(o Version) int
+
+	// Contains reports if o is covered by the version as it was written
+	// down: "8.4" contains "8.4.1" and "8.4.99".
+	Contains(o Version) bool
+
+	String() string
+}
+
+// SemVer is a semantic version, parsed by github.com/Masterminds/semver.
+// Compare and Contains ignore the pre-release part: for container images
+// that part mostly names a variant, which is handled by Tag.Variant.
+type SemVer struct {
+	*semver.Version
+}
+
+// Compare compares the versions v and o without their pre-release part
+func (v SemVer) Compare(o Version) int {
+
+	ov, ok := o.(SemVer)
+	if !ok {
+		return 0
+	}
+	return stripPrerelease(v.Version).Compare(stripPrerelease(ov.Version))
+}
+
+// Contains reports if o matches v up to the precision v was given in
+func (v SemVer) Contains(o Version) bool {
+
+	ov, ok := o.(SemVer)
+	if !ok {
+		return false
+	}
+
+	c, err := semver.NewConstraint(originalCore(v.Version))
+	if err != nil {
+		return false
+	}
+	return c.Check(stripPrerelease(ov.Version))
+}
+
+type semverScheme struct{}
+
+var errCalVerNotSemVer = errors.New("calendar version, not semver")
+
+func (semverScheme) Name() string { return "semver" }
+
+// Parse parses s into a semver Tag. Date based tags ("20210530") are
+// refused: semver would parse them into "20210530.0.0" which trumps every
+// regular version.
+func (semverScheme) Parse(s string) (*Tag, error) {
+	if _, _, _, err := ParseCalVer(s); err == nil {
+		return nil, errCalVerNotSemVer
+	}
+	v, err := semver.NewVersion(s)
+	if err != nil {
+		return nil, err
+	}
+	return New(v), nil
+}
+
+// Keep only keeps semantic versions within the major (=N), the minor
+// (=N.M) or the patch (=N.M.P) version of base. Components base was given
+// without are open: "1.25" keeps "1.25.x" at KeepPatch.
+func (semverScheme) Keep(base *Tag, keepLevel int) FilterFunc {
+
+	v := base.SemVer()
+	if v == nil {
+		return func(*Tag) bool { return false }
+	}
+
+	n := keptComponents(keepLevel)
+	if n == 0 {
+		return func(*Tag) bool { return true }
+	}
+	n = min(n, strings.Count(originalCore(v), ".")+1)
+
+	components := []uint64{v.Major(), v.Minor(), v.Patch()}
+	parts := make([]string, n)
+	for i := range parts {
+		parts[i] = fmt.Sprint(components[i])
+	}
+
+	c, err := semver.NewConstraint("=" + strings.Join(parts, "."))
+	if err != nil {
+		return func(*Tag) bool { return false }
+	}
+	return ConstraintFilter(c)
+}
+
+// asSemVer returns v as semantic version (without pre-release) to be
+// checked against semver.Constraints; nil if v can not be expressed as such
+func asSemVer(v Version) *semver.Version {
+
+	switch v := v.(type) {
+	case SemVer:
+		return stripPrerelease(v.Version)
+	case Numeric:
+		s := v.Segments
+		return semver.New(numericSegment(s, 0), numericSegment(s, 1), numericSegment(s, 2), "", "")
+	}
+	return nil
+}
+
+// stripPrerelease returns a copy of v without the pre-release part.
+//
+// if we dont do this, library "semver" considers "-label" to be a pre-release
+// version and then "1.0.0-label" to be below "1.0.0". container images use
+// that part mostly to name a variant, not a pre-release.
+func stripPrerelease(v *semver.Version) *semver.Version {
+	o, _ := v.SetPrerelease("")
+	return &o
+}
+
+// originalCore returns the original spelling of v without the pre-release
+// and the metadata part: "8.4-alpine" => "8.4"
+func originalCore(v *semver.Version) string {
+	core, _, _ := strings.Cut(v.Original(), "-")
+	core, _, _ = strings.Cut(core, "+")
+	return core
+}
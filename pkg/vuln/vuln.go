@@ -0,0 +1,237 @@
+package vuln
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/mgumz/cciu/pkg/imagespec"
+)
+
+// Severities lists the severities of vulnerabilities, most severe first
+var Severities = []string{"critical", "high", "medium", "low", "unknown"}
+
+// weights of the Severities to compute the Score of a Summary
+var weights = map[string]int{"critical": 1000, "high": 100, "medium": 10, "low": 1}
+
+// Vulnerability is a single finding of a scanner: the vulnerable package
+// in its installed version and the version fixing it ("" if none).
+// OSPackage tells whether the package comes with the distribution of the
+// image (apk, deb, rpm) rather than with the application.
+type Vulnerability struct {
+	ID        string
+	Package   string
+	Installed string
+	FixedIn   string
+	Severity  string
+	OSPackage bool
+}
+
+// Report is the scan report of an image, read from the JSON output of
+// Trivy or Grype. Images lists the names the scanned image was given as.
+type Report struct {
+	Images          []string
+	Vulnerabilities []Vulnerability
+}
+
+// Reports holds the reports of several images
+type Reports []*Report
+
+// Load reads the Trivy ("trivy image -f json") or Grype ("grype -o json")
+// report at path
+func Load(path string) (*Report, error) {
+
+	data, err := os.ReadFile(path) // #nosec G304
+	if err != nil {
+		return nil, err
+	}
+	return parse(data)
+}
+
+// parse parses data as a Trivy or a Grype report
+func parse(data []byte) (*Report, error) {
+
+	probe := struct {
+		Results json.RawMessage `json:"Results"`
+		Matches json.RawMessage `json:"matches"`
+	}{}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case probe.Results != nil:
+		return parseTrivy(data)
+	case probe.Matches != nil:
+		return parseGrype(data)
+	}
+	return nil, errors.New("neither a Trivy nor a Grype report")
+}
+
+// osPackageTypes are the package types of Grype denoting packages of the
+// distribution
+var osPackageTypes = []string{"apk", "deb", "rpm"}
+
+func parseTrivy(data []byte) (*Report, error) {
+
+	doc := struct {
+		ArtifactName string `json:"ArtifactName"`
+		Metadata     struct {
+			RepoTags []string `json:"RepoTags"`
+		} `json:"Metadata"`
+		Results []struct {
+			Class           string `json:"Class"`
+			Vulnerabilities []struct {
+				VulnerabilityID  string `json:"VulnerabilityID"`
+				PkgName          string `json:"PkgName"`
+				InstalledVersion string `json:"InstalledVersion"`
+				FixedVersion     string `json:"FixedVersion"`
+				Severity         string `json:"Severity"`
+			} `json:"Vulnerabilities"`
+		} `json:"Results"`
+	}{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	r := &Report{Images: append([]string{doc.ArtifactName}, doc.Metadata.RepoTags...)}
+	for _, result := range doc.Results {
+		for _, v := range result.Vulnerabilities {
+			r.Vulnerabilities = append(r.Vulnerabilities, Vulnerability{
+				ID:        v.VulnerabilityID,
+				Package:   v.PkgName,
+				Installed: v.InstalledVersion,
+				FixedIn:   v.FixedVersion,
+				Severity:  normalizeSeverity(v.Severity),
+				OSPackage: result.Class == "os-pkgs",
+			})
+		}
+	}
+	return r, nil
+}
+
+func parseGrype(data []byte) (*Report, error) {
+
+	doc := struct {
+		Matches []struct {
+			Vulnerability struct {
+				ID       string `json:"id"`
+				Severity string `json:"severity"`
+				Fix      struct {
+					Versions []string `json:"versions"`
+				} `json:"fix"`
+			} `json:"vulnerability"`
+			Artifact struct {
+				Name    string `json:"name"`
+				Version string `json:"version"`
+				Type    string `json:"type"`
+			} `json:"artifact"`
+		} `json:"matches"`
+		Source struct {
+			Target struct {
+				UserInput string   `json:"userInput"`
+				Tags      []string `json:"tags"`
+			} `json:"target"`
+		} `json:"source"`
+	}{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	r := &Report{Images: append([]string{doc.Source.Target.UserInput}, doc.Source.Target.Tags...)}
+	for _, m := range doc.Matches {
+		r.Vulnerabilities = append(r.Vulnerabilities, Vulnerability{
+			ID:        m.Vulnerability.ID,
+			Package:   m.Artifact.Name,
+			Installed: m.Artifact.Version,
+			FixedIn:   strings.Join(m.Vulnerability.Fix.Versions, ", "),
+			Severity:  normalizeSeverity(m.Vulnerability.Severity),
+			OSPackage: slices.Contains(osPackageTypes, m.Artifact.Type),
+		})
+	}
+	return r, nil
+}
+
+// normalizeSeverity maps the severities of the scanners to Severities:
+// "HIGH" => "high", "Negligible" => "low"
+func normalizeSeverity(s string) string {
+
+	s = strings.ToLower(s)
+	switch {
+	case s == "negligible":
+		return "low"
+	case !slices.Contains(Severities, s):
+		return "unknown"
+	}
+	return s
+}
+
+// Lookup returns the report of the image given by spec (including its tag);
+// nil if there is none. The names are compared in their normalized form.
+func (reports Reports) Lookup(spec *imagespec.Spec) *Report {
+
+	name := normalizedName(spec.String())
+	for _, r := range reports {
+		for _, image := range r.Images {
+			if image != "" && normalizedName(image) == name {
+				return r
+			}
+		}
+	}
+	return nil
+}
+
+// normalizedName returns name without its context part in its normalized
+// form: "alpine:3.19" => "docker.io/library/alpine:3.19"
+func normalizedName(name string) string {
+
+	spec, err := imagespec.Parse(name)
+	if err != nil {
+		return name
+	}
+	return spec.StripContext().Normalize().String()
+}
+
+// Summary sums up a report: the number of vulnerabilities per severity and
+// the number of vulnerabilities of OS packages with a fix available, which
+// a newer image likely contains
+type Summary struct {
+	Counts  map[string]int
+	Fixable int
+}
+
+// Summary sums up the report r
+func (r *Report) Summary() Summary {
+
+	s := Summary{Counts: map[string]int{}}
+	for _, v := range r.Vulnerabilities {
+		s.Counts[v.Severity]++
+		if v.OSPackage && v.FixedIn != "" {
+			s.Fixable++
+		}
+	}
+	return s
+}
+
+// Total returns the number of vulnerabilities
+func (s Summary) Total() int {
+
+	n := 0
+	for _, c := range s.Counts {
+		n += c
+	}
+	return n
+}
+
+// Score weights the vulnerabilities by their severity: one critical
+// vulnerability outweighs any number (< 10) of high ones, and so on
+func (s Summary) Score() int {
+
+	score := 0
+	for severity, c := range s.Counts {
+		score += weights[severity] * c
+	}
+	return score
+}
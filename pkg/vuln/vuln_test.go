@@ -0,0 +1,116 @@
+package vuln
+
+import (
+	"testing"
+
+	"github.com/mgumz/cciu/pkg/imagespec"
+)
+
+const trivyReport = `{
+  "ArtifactName": "alpine:3.19.1",
+  "Metadata": { "RepoTags": ["alpine:3.19.1", "alpine:3.19"] },
+  "Results": [
+    {
+      "Target": "alpine:3.19.1 (alpine 3.19.1)",
+      "Class": "os-pkgs",
+      "Vulnerabilities": [
+        { "VulnerabilityID": "CVE-2024-0727", "PkgName": "libssl3", "InstalledVersion": "3.1.4-r2", "FixedVersion": "3.1.4-r5", "Severity": "MEDIUM" },
+        { "VulnerabilityID": "CVE-2024-4741", "PkgName": "libcrypto3", "InstalledVersion": "3.1.4-r2", "FixedVersion": "", "Severity": "UNKNOWN" }
+      ]
+    },
+    {
+      "Target": "usr/bin/app",
+      "Class": "lang-pkgs",
+      "Vulnerabilities": [
+        { "VulnerabilityID": "CVE-2024-24790", "PkgName": "stdlib", "InstalledVersion": "1.21.5", "FixedVersion": "1.21.11", "Severity": "CRITICAL" }
+      ]
+    }
+  ]
+}`
+
+const grypeReport = `{
+  "matches": [
+    {
+      "vulnerability": { "id": "CVE-2023-5363", "severity": "High", "fix": { "versions": ["3.0.12-1~deb12u1"], "state": "fixed" } },
+      "artifact": { "name": "openssl", "version": "3.0.11-1~deb12u1", "type": "deb" }
+    },
+    {
+      "vulnerability": { "id": "CVE-2011-3374", "severity": "Negligible", "fix": { "versions": [], "state": "not-fixed" } },
+      "artifact": { "name": "apt", "version": "2.6.1", "type": "deb" }
+    },
+    {
+      "vulnerability": { "id": "GHSA-xxxx", "severity": "Critical", "fix": { "versions": ["4.17.21"], "state": "fixed" } },
+      "artifact": { "name": "lodash", "version": "4.17.15", "type": "npm" }
+    }
+  ],
+  "source": { "type": "image", "target": { "userInput": "quay.io/org/app:2.4.0", "tags": [] } }
+}`
+
+func TestParse(t *testing.T) {
+
+	fixtures := [...]struct {
+		Report   string
+		Counts   map[string]int
+		Fixable  int
+		Score    int
+		Expected string // image the report is found for
+	}{
+		{trivyReport, map[string]int{"critical": 1, "medium": 1, "unknown": 1}, 1, 1010, "docker.io/library/alpine:3.19"},
+		{grypeReport, map[string]int{"critical": 1, "high": 1, "low": 1}, 1, 1101, "quay.io/org/app:2.4.0@sha256:abc"},
+	}
+
+	for _, f := range fixtures {
+		r, err := parse([]byte(f.Report))
+		if err != nil {
+			t.Fatalf("expected: no error, actual: %s", err)
+		}
+		s := r.Summary()
+		for _, severity := range Severities {
+			if s.Counts[severity] != f.Counts[severity] {
+				t.Fatalf("%s: expected: %d, actual: %d", severity, f.Counts[severity], s.Counts[severity])
+			}
+		}
+		if s.Fixable != f.Fixable {
+			t.Fatalf("expected: %d, actual: %d", f.Fixable, s.Fixable)
+		}
+		if s.Score() != f.Score {
+			t.Fatalf("expected: %d, actual: %d", f.Score, s.Score())
+		}
+		spec, _ := imagespec.Parse(f.Expected)
+		if (Reports{r}).Lookup(spec) != r {
+			t.Fatalf("expected: report for %q, actual: none", f.Expected)
+		}
+	}
+}
+
+func TestLookup(t *testing.T) {
+
+	trivy, _ := parse([]byte(trivyReport))
+	grype, _ := parse([]byte(grypeReport))
+	reports := Reports{trivy, grype}
+
+	fixtures := [...]struct {
+		Name     string
+		Expected *Report
+	}{
+		{"alpine:3.19.1", trivy},
+		{"docker.io/library/alpine:3.19.1", trivy},
+		{"alpine:3.18", nil},
+		{"quay.io/org/app:2.4.0", grype},
+		{"quay.io/org/app:2.5.0", nil},
+	}
+
+	for _, f := range fixtures {
+		spec, _ := imagespec.Parse(f.Name)
+		if actual := reports.Lookup(spec); actual != f.Expected {
+			t.Fatalf("%q: expected: %p, actual: %p", f.Name, f.Expected, actual)
+		}
+	}
+}
+
+func TestParseUnknown(t *testing.T) {
+
+	if _, err := parse([]byte(`{"bomFormat": "CycloneDX"}`)); err == nil {
+		t.Fatalf("expected: error, actual: none")
+	}
+}